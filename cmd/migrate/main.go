@@ -1,12 +1,18 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"portfolio-server/internal/config"
 	"portfolio-server/internal/database"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/services"
 )
 
 func main() {
+	adminEmail := flag.String("admin", "", "마이그레이션 후 admin 역할을 부여할 사용자 이메일")
+	flag.Parse()
+
 	cfg := config.LoadConfig()
 
 	if err := database.InitDatabase(cfg); err != nil {
@@ -17,5 +23,33 @@ func main() {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	if err := services.BackfillArticleSlugs(); err != nil {
+		log.Fatalf("Failed to backfill article slugs: %v", err)
+	}
+
+	if err := services.BackfillArticleContent(); err != nil {
+		log.Fatalf("Failed to render article content: %v", err)
+	}
+
+	// 컬럼이 추가될 때는 AutoMigrate가 채우고, 이후 어긋난 개수는 이 명령으로 바로잡습니다
+	if err := database.RecountAllComments(database.GetDB()); err != nil {
+		log.Fatalf("Failed to recount comments: %v", err)
+	}
+
+	if err := database.RecountAllReactions(database.GetDB()); err != nil {
+		log.Fatalf("Failed to recount reactions: %v", err)
+	}
+
 	log.Println("Migration completed successfully!")
+
+	if *adminEmail != "" {
+		result := database.GetDB().Model(&models.User{}).Where("email = ?", *adminEmail).Update("role", models.RoleAdmin)
+		if result.Error != nil {
+			log.Fatalf("Failed to grant admin role: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			log.Fatalf("User not found: %s", *adminEmail)
+		}
+		log.Printf("Granted admin role to %s", *adminEmail)
+	}
 }
@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
 	"portfolio-server/internal/config"
 	"portfolio-server/internal/database"
 	"portfolio-server/internal/middleware"
 	"portfolio-server/internal/routes"
+	"portfolio-server/internal/services"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -29,7 +36,34 @@ func main() {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
-	middleware.InitJWT(&cfg.JWT)
+	if err := services.BackfillArticleSlugs(); err != nil {
+		log.Fatalf("Failed to backfill article slugs: %v", err)
+	}
+
+	if err := services.BackfillArticleContent(); err != nil {
+		log.Fatalf("Failed to render article content: %v", err)
+	}
+
+	if err := cfg.Server.Validate(); err != nil {
+		log.Fatalf("Invalid server config: %v", err)
+	}
+
+	if err := middleware.InitJWT(&cfg.JWT, cfg.Server.ENV); err != nil {
+		log.Fatalf("Failed to initialize JWT: %v", err)
+	}
+
+	if err := services.ValidateAccountConfig(&cfg.Account); err != nil {
+		log.Fatalf("Invalid account config: %v", err)
+	}
+
+	// SIGINT, SIGTERM을 받으면 요청 처리와 백그라운드 작업을 정리하고 종료합니다
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	services.NewArticleScheduler().Start(ctx)
+	services.NewViewFlusher().Start(ctx)
+	services.NewRankingRefresher().Start(ctx)
+	services.NewStatsRollup().Start(ctx)
 
 	if cfg.Server.ENV == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -48,8 +82,21 @@ func main() {
 	routes.SetupRoutes(router)
 
 	addr := ":" + cfg.Server.Port
-	log.Printf("Server starting on %s", addr)
-	if err := router.Run(addr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	server := &http.Server{Addr: addr, Handler: router}
+	go func() {
+		log.Printf("Server starting on %s", addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down server: %v", err)
 	}
+	services.WaitForWorkers()
 }
@@ -4,8 +4,15 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
+// DefaultJWTSecret is used when JWT_SECRET is not set. The server refuses to start with it in production.
+const DefaultJWTSecret = "your-secret-key-change-this"
+
+// DefaultFrontendURL is used when FRONTEND_URL is not set. The server refuses to start with it in production.
+const DefaultFrontendURL = "http://localhost:3000"
+
 type Config struct {
 	Database DatabaseConfig
 	Server   ServerConfig
@@ -13,6 +20,16 @@ type Config struct {
 	SMTP     SMTPConfig
 	Redis    RedisConfig
 	MinIO    MinIOConfig
+
+	Verification VerificationConfig
+	Account      AccountConfig
+	TwoFactor    TwoFactorConfig
+	OAuth        OAuthConfig
+	Login        LoginSecurityConfig
+	Article      ArticleConfig
+	Search       SearchConfig
+	View         ViewConfig
+	Ranking      RankingConfig
 }
 
 type DatabaseConfig struct {
@@ -26,11 +43,28 @@ type DatabaseConfig struct {
 type ServerConfig struct {
 	Port string
 	ENV  string
+	// 메일에 포함되는 링크(비밀번호 재설정 등)의 기준 주소
+	FrontendURL string
+}
+
+// Validate rejects a production server whose email links and OAuth redirects would point at localhost
+func (c *ServerConfig) Validate() error {
+	if c.ENV == "production" && (c.FrontendURL == "" || c.FrontendURL == DefaultFrontendURL) {
+		return fmt.Errorf("FRONTEND_URL must be set to the public frontend address in production")
+	}
+	return nil
 }
 
+// JWTConfig configures access token signing.
+// Algorithm is HS256 (Secret), RS256 or EdDSA (PrivateKeyFile). VerificationKeyFiles lists public keys
+// that are still accepted, e.g. the previous key during rotation, and are published in the JWKS.
 type JWTConfig struct {
-	Secret          string
-	ExpirationHours int
+	Algorithm               string
+	Secret                  string
+	PrivateKeyFile          string
+	VerificationKeyFiles    []string
+	AccessExpirationMinutes int
+	RefreshExpirationDays   int
 }
 
 type SMTPConfig struct {
@@ -45,6 +79,104 @@ type RedisConfig struct {
 	Port string
 }
 
+// VerificationConfig limits how often verification codes can be sent and guessed
+type VerificationConfig struct {
+	ResendCooldownSeconds int
+	DailySendLimit        int
+	MaxAttempts           int
+	IPSendLimitPerHour    int
+	IPVerifyLimitPerHour  int
+}
+
+// AccountConfig controls what happens to a user's content when the account is deleted.
+// DeletionContentPolicy is "anonymize" (keep articles and comments without the author) or "cascade" (delete them).
+type AccountConfig struct {
+	DeletionContentPolicy string
+}
+
+// LoginSecurityConfig limits password guessing.
+// After FreeAttempts failures for an email each further attempt is delayed (1s, 2s, 4s, ... up to MaxDelaySeconds),
+// and LockoutThreshold failures within the window lock the email for LockoutMinutes.
+type LoginSecurityConfig struct {
+	FreeAttempts         int
+	MaxDelaySeconds      int
+	LockoutThreshold     int
+	LockoutMinutes       int
+	FailureWindowMinutes int
+	IPFailureLimit       int
+	// 이 횟수만큼 실패하면 계정 소유자에게 알림 메일을 보냅니다
+	AlertThreshold int
+}
+
+// ArticleConfig configures the background publisher for scheduled articles and slug generation
+type ArticleConfig struct {
+	// 예약된 글을 공개 상태로 바꾸는 주기
+	SchedulerIntervalSeconds int
+	// true면 슬러그에 한글을 로마자로 바꾸지 않고 그대로 둡니다
+	SlugKeepHangul bool
+	// 제목에서 슬러그로 쓸 문자가 하나도 나오지 않을 때 사용할 슬러그
+	SlugFallback string
+}
+
+// SearchConfig configures full-text search.
+// TextSearchConfig is the Postgres text search configuration used for the tsvector columns ("simple" works for
+// any language). Since Postgres has no Korean configuration, SubstringMatch additionally matches the query as a
+// substring, backed by pg_trgm indexes, so that "언어" finds "언어는".
+type SearchConfig struct {
+	TextSearchConfig string
+	SubstringMatch   bool
+}
+
+// ViewConfig configures view counting. Views are counted once per viewer per DedupeMinutes and
+// buffered in Redis until the next flush to Postgres.
+type ViewConfig struct {
+	DedupeMinutes        int
+	FlushIntervalSeconds int
+	// 일별 통계(article_daily_stats)를 갱신하는 주기
+	RollupIntervalSeconds int
+}
+
+// RankingConfig configures trending articles and the period leaderboards, which are recomputed
+// every RefreshIntervalSeconds. Trending scores the views and comments of the last TrendingDays,
+// halving the weight of a day's activity every TrendingHalfLifeHours.
+type RankingConfig struct {
+	// 순위에 포함하는 글 수
+	Size                   int
+	RefreshIntervalSeconds int
+	TrendingDays           int
+	TrendingHalfLifeHours  int
+	// 댓글, 반응 하나를 조회 몇 번으로 칠지
+	CommentWeight  int
+	ReactionWeight int
+}
+
+// TwoFactorConfig configures TOTP enrollment
+type TwoFactorConfig struct {
+	// 인증 앱에 표시되는 서비스 이름
+	Issuer string
+}
+
+// OAuthConfig lists the enabled social login providers (OAUTH_PROVIDERS=github,google)
+type OAuthConfig struct {
+	Providers []OAuthProviderConfig
+}
+
+// OAuthProviderConfig configures one authorization code + PKCE client.
+// When Issuer is set the endpoints are read from <Issuer>/.well-known/openid-configuration
+// and the ID token is verified; explicitly set endpoints take precedence.
+type OAuthProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	Issuer       string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	JWKSURL      string
+	Scopes       []string
+	RedirectURL  string
+}
+
 type MinIOConfig struct {
 	Endpoint  string
 	AccessKey string
@@ -65,10 +197,16 @@ func LoadConfig() *Config {
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
 			ENV:  getEnv("ENV", "development"),
+
+			FrontendURL: getEnv("FRONTEND_URL", DefaultFrontendURL),
 		},
 		JWT: JWTConfig{
-			Secret:          getEnv("JWT_SECRET", "your-secret-key-change-this"),
-			ExpirationHours: getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
+			Algorithm:               getEnv("JWT_ALGORITHM", "HS256"),
+			Secret:                  getEnv("JWT_SECRET", DefaultJWTSecret),
+			PrivateKeyFile:          getEnv("JWT_PRIVATE_KEY_FILE", ""),
+			VerificationKeyFiles:    getEnvAsList("JWT_VERIFICATION_KEY_FILES"),
+			AccessExpirationMinutes: getEnvAsInt("JWT_ACCESS_EXPIRATION_MINUTES", 15),
+			RefreshExpirationDays:   getEnvAsInt("JWT_REFRESH_EXPIRATION_DAYS", 14),
 		},
 		SMTP: SMTPConfig{
 			Host:     getEnv("SMTP_HOST", "smtp.gmail.com"),
@@ -87,7 +225,97 @@ func LoadConfig() *Config {
 			Bucket:    getEnv("MINIO_BUCKET", "kasdfasdfa"),
 			UseSSL:    getEnvAsBool("MINIO_USE_SSL", true),
 		},
+		Verification: VerificationConfig{
+			ResendCooldownSeconds: getEnvAsInt("VERIFICATION_RESEND_COOLDOWN_SECONDS", 60),
+			DailySendLimit:        getEnvAsInt("VERIFICATION_DAILY_SEND_LIMIT", 10),
+			MaxAttempts:           getEnvAsInt("VERIFICATION_MAX_ATTEMPTS", 5),
+			IPSendLimitPerHour:    getEnvAsInt("VERIFICATION_IP_SEND_LIMIT_PER_HOUR", 20),
+			IPVerifyLimitPerHour:  getEnvAsInt("VERIFICATION_IP_VERIFY_LIMIT_PER_HOUR", 60),
+		},
+		Account: AccountConfig{
+			DeletionContentPolicy: getEnv("ACCOUNT_DELETION_CONTENT_POLICY", "anonymize"),
+		},
+		TwoFactor: TwoFactorConfig{
+			Issuer: getEnv("TOTP_ISSUER", "Portfolio"),
+		},
+		Login: LoginSecurityConfig{
+			FreeAttempts:         getEnvAsInt("LOGIN_FREE_ATTEMPTS", 3),
+			MaxDelaySeconds:      getEnvAsInt("LOGIN_MAX_DELAY_SECONDS", 60),
+			LockoutThreshold:     getEnvAsInt("LOGIN_LOCKOUT_THRESHOLD", 10),
+			LockoutMinutes:       getEnvAsInt("LOGIN_LOCKOUT_MINUTES", 15),
+			FailureWindowMinutes: getEnvAsInt("LOGIN_FAILURE_WINDOW_MINUTES", 15),
+			IPFailureLimit:       getEnvAsInt("LOGIN_IP_FAILURE_LIMIT", 50),
+			AlertThreshold:       getEnvAsInt("LOGIN_ALERT_THRESHOLD", 5),
+		},
+		Article: ArticleConfig{
+			SchedulerIntervalSeconds: getEnvAsInt("ARTICLE_SCHEDULER_INTERVAL_SECONDS", 30),
+			SlugKeepHangul:           getEnvAsBool("ARTICLE_SLUG_KEEP_HANGUL", false),
+			SlugFallback:             getEnv("ARTICLE_SLUG_FALLBACK", "post"),
+		},
+		Search: SearchConfig{
+			TextSearchConfig: getEnv("SEARCH_TEXT_CONFIG", "simple"),
+			SubstringMatch:   getEnvAsBool("SEARCH_SUBSTRING_MATCH", true),
+		},
+		View: ViewConfig{
+			DedupeMinutes:         getEnvAsInt("VIEW_DEDUPE_MINUTES", 30),
+			FlushIntervalSeconds:  getEnvAsInt("VIEW_FLUSH_INTERVAL_SECONDS", 10),
+			RollupIntervalSeconds: getEnvAsInt("VIEW_ROLLUP_INTERVAL_SECONDS", 300),
+		},
+		Ranking: RankingConfig{
+			Size:                   getEnvAsInt("RANKING_SIZE", 10),
+			RefreshIntervalSeconds: getEnvAsInt("RANKING_REFRESH_INTERVAL_SECONDS", 300),
+			TrendingDays:           getEnvAsInt("RANKING_TRENDING_DAYS", 7),
+			TrendingHalfLifeHours:  getEnvAsInt("RANKING_TRENDING_HALF_LIFE_HOURS", 24),
+			CommentWeight:          getEnvAsInt("RANKING_COMMENT_WEIGHT", 5),
+			ReactionWeight:         getEnvAsInt("RANKING_REACTION_WEIGHT", 2),
+		},
+		OAuth: loadOAuthConfig(getEnv("FRONTEND_URL", DefaultFrontendURL)),
+	}
+}
+
+// 잘 알려진 제공자의 기본값. 환경변수로 덮어쓸 수 있습니다
+var oauthProviderDefaults = map[string]OAuthProviderConfig{
+	"google": {
+		Issuer: "https://accounts.google.com",
+		Scopes: []string{"openid", "email", "profile"},
+	},
+	"github": {
+		AuthURL:     "https://github.com/login/oauth/authorize",
+		TokenURL:    "https://github.com/login/oauth/access_token",
+		UserInfoURL: "https://api.github.com/user",
+		Scopes:      []string{"read:user", "user:email"},
+	},
+}
+
+// loadOAuthConfig reads OAUTH_<NAME>_* variables for every provider in OAUTH_PROVIDERS
+func loadOAuthConfig(frontendURL string) OAuthConfig {
+	var cfg OAuthConfig
+	for _, name := range getEnvAsList("OAUTH_PROVIDERS") {
+		name = strings.ToLower(name)
+		prefix := "OAUTH_" + strings.ToUpper(name) + "_"
+		defaults := oauthProviderDefaults[name]
+
+		provider := OAuthProviderConfig{
+			Name:         name,
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			Issuer:       getEnv(prefix+"ISSUER", defaults.Issuer),
+			AuthURL:      getEnv(prefix+"AUTH_URL", defaults.AuthURL),
+			TokenURL:     getEnv(prefix+"TOKEN_URL", defaults.TokenURL),
+			UserInfoURL:  getEnv(prefix+"USERINFO_URL", defaults.UserInfoURL),
+			JWKSURL:      getEnv(prefix+"JWKS_URL", defaults.JWKSURL),
+			Scopes:       getEnvAsList(prefix + "SCOPES"),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", fmt.Sprintf("%s/oauth/%s/callback", strings.TrimRight(frontendURL, "/"), name)),
+		}
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = defaults.Scopes
+		}
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = []string{"openid", "email", "profile"}
+		}
+		cfg.Providers = append(cfg.Providers, provider)
 	}
+	return cfg
 }
 
 func (c *DatabaseConfig) GetDSN() string {
@@ -121,3 +349,14 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// getEnvAsList splits a comma separated value and drops empty entries
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -39,22 +39,133 @@ func InitDatabase(cfg *config.Config) error {
 }
 
 func AutoMigrate() error {
+	// 카운터 컬럼이 이번에 처음 추가되면 기본값 0 대신 실제 개수로 한 번 채웁니다
+	migrator := DB.Migrator()
+	addsCommentCount := !migrator.HasColumn(&models.Article{}, "comment_count")
+	addsArticleReactions := !migrator.HasColumn(&models.Article{}, "reaction_counts")
+	addsCommentReactions := !migrator.HasColumn(&models.Comment{}, "reaction_counts")
+
 	err := DB.AutoMigrate(
 		&models.User{},
 		&models.Category{},
 		&models.Article{},
 		&models.ArticleCategory{},
+		&models.ArticleRevision{},
+		&models.ArticleSlug{},
+		&models.ArticleDailyStat{},
 		&models.Comment{},
+		&models.Reaction{},
+		&models.Bookmark{},
 		&models.VerificationCode{},
+		&models.APIKey{},
+		&models.RecoveryCode{},
+		&models.UserIdentity{},
+		&models.LoginAttempt{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if err := migrateSearch(config.LoadConfig().Search); err != nil {
+		return fmt.Errorf("failed to migrate search indexes: %w", err)
+	}
+
+	// 상태 도입 이전에 작성된 글은 작성 시각에 공개된 것으로 봅니다
+	if err := DB.Exec("UPDATE articles SET published_at = created_at WHERE status = ? AND published_at IS NULL", "published").Error; err != nil {
+		return fmt.Errorf("failed to backfill article published_at: %w", err)
+	}
+
+	if addsCommentCount {
+		if err := RecountAllComments(DB); err != nil {
+			return fmt.Errorf("failed to backfill comment counts: %w", err)
+		}
+	}
+	if addsArticleReactions {
+		if err := recountReactions(DB, models.ReactionTargetArticle, nil); err != nil {
+			return fmt.Errorf("failed to backfill article reaction counts: %w", err)
+		}
+	}
+	if addsCommentReactions {
+		if err := recountReactions(DB, models.ReactionTargetComment, nil); err != nil {
+			return fmt.Errorf("failed to backfill comment reaction counts: %w", err)
+		}
+	}
+
 	log.Println("Database migration completed successfully")
 	return nil
 }
 
+// RecountAllComments sets articles.comment_count from the comments table for every article where it drifted.
+// It reads the whole comments table, so the server only runs it when AutoMigrate adds the column;
+// the migrate command runs it every time to repair counts.
+func RecountAllComments(db *gorm.DB) error {
+	return recountComments(db, nil)
+}
+
+// RecountComments is RecountAllComments for the given articles only
+func RecountComments(db *gorm.DB, articleIDs []uint) error {
+	if len(articleIDs) == 0 {
+		return nil
+	}
+	return recountComments(db, articleIDs)
+}
+
+// recountComments recounts the given articles, or every article when articleIDs is nil
+func recountComments(db *gorm.DB, articleIDs []uint) error {
+	filter, args := "TRUE", []interface{}{}
+	if articleIDs != nil {
+		filter, args = "articles.id IN ?", []interface{}{articleIDs}
+	}
+	return db.Exec(`UPDATE articles SET comment_count = counted.total
+		FROM (SELECT articles.id, COUNT(comments.id) AS total
+			FROM articles LEFT JOIN comments ON comments.article_id = articles.id
+			WHERE `+filter+`
+			GROUP BY articles.id) AS counted
+		WHERE articles.id = counted.id AND articles.comment_count <> counted.total`, args...).Error
+}
+
+// RecountAllReactions sets reaction_counts of every article and comment from the reactions table wherever it drifted.
+// Like RecountAllComments the server only runs it when the column is added.
+func RecountAllReactions(db *gorm.DB) error {
+	for _, targetType := range []string{models.ReactionTargetArticle, models.ReactionTargetComment} {
+		if err := recountReactions(db, targetType, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecountReactions is RecountAllReactions for the given articles or comments (see models.ReactionTargetArticle) only
+func RecountReactions(db *gorm.DB, targetType string, targetIDs []uint) error {
+	if len(targetIDs) == 0 {
+		return nil
+	}
+	return recountReactions(db, targetType, targetIDs)
+}
+
+// recountReactions recounts the given targets, or every target of the type when targetIDs is nil
+func recountReactions(db *gorm.DB, targetType string, targetIDs []uint) error {
+	table := "articles"
+	if targetType == models.ReactionTargetComment {
+		table = "comments"
+	}
+	targetFilter, reactionFilter, args := "TRUE", "TRUE", []interface{}{targetType}
+	if targetIDs != nil {
+		targetFilter, reactionFilter = "target.id IN ?", "target_id IN ?"
+		args = append(args, targetIDs, targetIDs)
+	}
+
+	return db.Exec(`UPDATE `+table+` SET reaction_counts = counted.counts
+		FROM (SELECT target.id, COALESCE(grouped.counts, '{}'::jsonb) AS counts
+			FROM `+table+` AS target
+			LEFT JOIN (SELECT target_id, jsonb_object_agg(type, total) AS counts
+				FROM (SELECT target_id, type, COUNT(*) AS total FROM reactions
+					WHERE target_type = ? AND `+reactionFilter+` GROUP BY target_id, type) AS totals
+				GROUP BY target_id) AS grouped ON grouped.target_id = target.id
+			WHERE `+targetFilter+`) AS counted
+		WHERE `+table+`.id = counted.id AND `+table+`.reaction_counts IS DISTINCT FROM counted.counts`, args...).Error
+}
+
 func GetDB() *gorm.DB {
 	return DB
 }
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"portfolio-server/internal/config"
+	"regexp"
+	"strings"
+)
+
+var textSearchConfigPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// searchColumns lists the tsvector columns kept up to date by Postgres as generated columns.
+// 제목은 가중치 A, 본문은 가중치 B로 순위에 반영됩니다.
+var searchColumns = []struct {
+	table      string
+	expression string
+}{
+	{"articles", "setweight(to_tsvector('%[1]s', coalesce(title, '')), 'A') || setweight(to_tsvector('%[1]s', coalesce(content, '')), 'B')"},
+	{"comments", "to_tsvector('%[1]s', coalesce(content, ''))"},
+}
+
+// 부분 문자열 검색(ILIKE)에 쓰이는 trigram 인덱스
+var trigramIndexes = []struct {
+	name   string
+	table  string
+	column string
+}{
+	{"idx_articles_title_trgm", "articles", "title"},
+	{"idx_articles_content_trgm", "articles", "content"},
+	{"idx_comments_content_trgm", "comments", "content"},
+}
+
+// migrateSearch creates the search_vector columns with their GIN indexes and, when substring matching
+// is enabled, the pg_trgm indexes. Columns built with a different text search configuration are rebuilt.
+func migrateSearch(cfg config.SearchConfig) error {
+	if !textSearchConfigPattern.MatchString(cfg.TextSearchConfig) {
+		return fmt.Errorf("invalid text search config: %q", cfg.TextSearchConfig)
+	}
+
+	for _, column := range searchColumns {
+		expression := fmt.Sprintf(column.expression, cfg.TextSearchConfig)
+
+		var current string
+		err := DB.Raw(`SELECT pg_get_expr(d.adbin, d.adrelid)
+			FROM pg_attribute a JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+			WHERE a.attrelid = ?::regclass AND a.attname = 'search_vector' AND NOT a.attisdropped`, column.table).
+			Scan(&current).Error
+		if err != nil {
+			return err
+		}
+
+		if current != "" && !strings.Contains(current, fmt.Sprintf("'%s'::regconfig", cfg.TextSearchConfig)) {
+			log.Printf("Rebuilding %s.search_vector with text search config %q", column.table, cfg.TextSearchConfig)
+			if err := DB.Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN search_vector", column.table)).Error; err != nil {
+				return err
+			}
+			current = ""
+		}
+
+		if current == "" {
+			if err := DB.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (%s) STORED", column.table, expression)).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := DB.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%[1]s_search_vector ON %[1]s USING GIN (search_vector)", column.table)).Error; err != nil {
+			return err
+		}
+	}
+
+	if !cfg.SubstringMatch {
+		return nil
+	}
+
+	// 확장을 만들 권한이 없어도 검색은 동작하므로 인덱스 없이 계속합니다
+	if err := DB.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		log.Printf("pg_trgm is not available, substring search will not use indexes: %v", err)
+		return nil
+	}
+	for _, index := range trigramIndexes {
+		if err := DB.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING GIN (%s gin_trgm_ops)", index.name, index.table, index.column)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,10 @@ type AppError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Detail  string `json:"detail,omitempty"`
+	// Reason은 클라이언트가 분기 처리할 수 있는 고정 식별자입니다 (예: "resend_cooldown")
+	Reason string `json:"reason,omitempty"`
+	// RetryAfter는 다시 시도할 수 있을 때까지 남은 시간(초)입니다
+	RetryAfter int `json:"retry_after,omitempty"`
 }
 
 func (e *AppError) Error() string {
@@ -23,6 +27,21 @@ func NewAppError(code int, message string, detail string) *AppError {
 	}
 }
 
+// WithReason sets a machine readable reason on the error
+func (e *AppError) WithReason(reason string) *AppError {
+	e.Reason = reason
+	return e
+}
+
+// WithRetryAfter sets the number of seconds the client should wait before retrying
+func (e *AppError) WithRetryAfter(seconds int) *AppError {
+	if seconds < 1 {
+		seconds = 1
+	}
+	e.RetryAfter = seconds
+	return e
+}
+
 var (
 	ErrBadRequest          = NewAppError(http.StatusBadRequest, "잘못된 요청입니다", "")
 	ErrUnauthorized        = NewAppError(http.StatusUnauthorized, "인증이 필요합니다", "")
@@ -40,10 +59,22 @@ func ErrArticleNotFound() *AppError {
 	return NewAppError(http.StatusNotFound, "게시글을 찾을 수 없습니다", "요청한 게시글이 존재하지 않습니다")
 }
 
+func ErrRevisionNotFound() *AppError {
+	return NewAppError(http.StatusNotFound, "버전을 찾을 수 없습니다", "요청한 게시글 버전이 존재하지 않습니다")
+}
+
 func ErrCommentNotFound() *AppError {
 	return NewAppError(http.StatusNotFound, "댓글을 찾을 수 없습니다", "요청한 댓글이 존재하지 않습니다")
 }
 
+func ErrSessionNotFound() *AppError {
+	return NewAppError(http.StatusNotFound, "세션을 찾을 수 없습니다", "이미 종료되었거나 존재하지 않는 세션입니다")
+}
+
+func ErrAPIKeyNotFound() *AppError {
+	return NewAppError(http.StatusNotFound, "API 키를 찾을 수 없습니다", "요청한 API 키가 존재하지 않거나 이미 삭제되었습니다")
+}
+
 func ErrInvalidCredentials() *AppError {
 	return NewAppError(http.StatusUnauthorized, "로그인 정보가 올바르지 않습니다", "이메일 또는 비밀번호가 일치하지 않습니다")
 }
@@ -56,10 +87,139 @@ func ErrUsernameAlreadyExists() *AppError {
 	return NewAppError(http.StatusConflict, "이미 사용 중인 사용자명입니다", "해당 사용자명은 이미 다른 사용자가 사용하고 있습니다")
 }
 
+func ErrIncorrectPassword() *AppError {
+	return NewAppError(http.StatusBadRequest, "비밀번호가 올바르지 않습니다", "현재 비밀번호가 일치하지 않습니다").
+		WithReason("incorrect_password")
+}
+
+// ErrReauthCodeRequired is returned when an account without a password confirms a sensitive change without a code
+func ErrReauthCodeRequired() *AppError {
+	return NewAppError(http.StatusBadRequest, "인증 코드가 필요합니다", "비밀번호가 없는 계정은 이메일로 받은 인증 코드로 본인 확인을 해야 합니다").
+		WithReason("reauth_code_required")
+}
+
+func ErrEmailNotVerified() *AppError {
+	return NewAppError(http.StatusBadRequest, "이메일 인증이 필요합니다", "인증 토큰이 없거나 만료되었습니다. 이메일 인증을 다시 진행해주세요")
+}
+
 func ErrInvalidInput(detail string) *AppError {
 	return NewAppError(http.StatusBadRequest, "입력 값이 올바르지 않습니다", detail)
 }
 
+func ErrInvalidCursor() *AppError {
+	return NewAppError(http.StatusBadRequest, "커서가 올바르지 않습니다", "cursor는 같은 정렬 기준으로 받은 next_cursor 값이어야 합니다").
+		WithReason("invalid_cursor")
+}
+
 func ErrPermissionDenied() *AppError {
 	return NewAppError(http.StatusForbidden, "권한이 없습니다", "이 작업을 수행할 권한이 없습니다")
 }
+
+func ErrInvalidRefreshToken() *AppError {
+	return NewAppError(http.StatusUnauthorized, "Refresh Token이 유효하지 않습니다", "토큰이 만료되었거나 로그아웃된 세션입니다")
+}
+
+func ErrRefreshTokenReused() *AppError {
+	return NewAppError(http.StatusUnauthorized, "이미 사용된 Refresh Token입니다", "토큰 재사용이 감지되어 해당 세션이 종료되었습니다. 다시 로그인해주세요")
+}
+
+func ErrTooManyRequests(retryAfter int) *AppError {
+	return NewAppError(http.StatusTooManyRequests, "요청이 너무 많습니다", "잠시 후 다시 시도해주세요").
+		WithReason("rate_limited").
+		WithRetryAfter(retryAfter)
+}
+
+func ErrLoginThrottled(retryAfter int) *AppError {
+	return NewAppError(http.StatusTooManyRequests, "로그인 시도가 너무 잦습니다", "잠시 후 다시 시도해주세요").
+		WithReason("login_throttled").
+		WithRetryAfter(retryAfter)
+}
+
+func ErrAccountLocked(retryAfter int) *AppError {
+	return NewAppError(http.StatusTooManyRequests, "로그인 실패가 반복되어 잠시 로그인이 제한되었습니다", "잠시 후 다시 시도하거나 비밀번호를 재설정해주세요").
+		WithReason("account_locked").
+		WithRetryAfter(retryAfter)
+}
+
+func ErrVerificationCooldown(retryAfter int) *AppError {
+	return NewAppError(http.StatusTooManyRequests, "인증 코드를 너무 자주 요청했습니다", "잠시 후 다시 요청해주세요").
+		WithReason("resend_cooldown").
+		WithRetryAfter(retryAfter)
+}
+
+func ErrVerificationDailyLimit(retryAfter int) *AppError {
+	return NewAppError(http.StatusTooManyRequests, "인증 코드 발송 한도를 초과했습니다", "하루에 요청할 수 있는 인증 코드 수를 초과했습니다").
+		WithReason("daily_limit_exceeded").
+		WithRetryAfter(retryAfter)
+}
+
+func ErrInvalidVerificationCode(remainingAttempts int) *AppError {
+	return NewAppError(http.StatusBadRequest, "인증 코드가 유효하지 않습니다", fmt.Sprintf("코드가 일치하지 않습니다 (남은 시도 횟수: %d회)", remainingAttempts)).
+		WithReason("invalid_code")
+}
+
+func ErrVerificationCodeExpired() *AppError {
+	return NewAppError(http.StatusBadRequest, "인증 코드가 유효하지 않습니다", "코드를 찾을 수 없거나 만료되었습니다").
+		WithReason("code_expired")
+}
+
+func ErrVerificationCodeInvalidated() *AppError {
+	return NewAppError(http.StatusBadRequest, "인증 시도 횟수를 초과했습니다", "인증 코드가 무효화되었습니다. 새 인증 코드를 요청해주세요").
+		WithReason("code_invalidated")
+}
+
+func ErrInvalidPasswordResetToken() *AppError {
+	return NewAppError(http.StatusBadRequest, "비밀번호 재설정 링크가 유효하지 않습니다", "링크가 만료되었거나 이미 사용되었습니다. 비밀번호 재설정을 다시 요청해주세요").
+		WithReason("invalid_reset_token")
+}
+
+func ErrInvalidMagicLink() *AppError {
+	return NewAppError(http.StatusBadRequest, "로그인 링크가 유효하지 않습니다", "링크가 만료되었거나 이미 사용되었습니다. 로그인 링크를 다시 요청해주세요").
+		WithReason("invalid_magic_link")
+}
+
+func ErrTwoFactorAlreadyEnabled() *AppError {
+	return NewAppError(http.StatusConflict, "2단계 인증이 이미 활성화되어 있습니다", "다시 등록하려면 먼저 2단계 인증을 해제해주세요")
+}
+
+func ErrTwoFactorNotEnabled() *AppError {
+	return NewAppError(http.StatusBadRequest, "2단계 인증이 활성화되어 있지 않습니다", "")
+}
+
+func ErrTwoFactorSetupExpired() *AppError {
+	return NewAppError(http.StatusBadRequest, "2단계 인증 등록 시간이 만료되었습니다", "등록을 처음부터 다시 진행해주세요").
+		WithReason("setup_expired")
+}
+
+func ErrInvalidTwoFactorCode() *AppError {
+	return NewAppError(http.StatusUnauthorized, "인증 코드가 올바르지 않습니다", "인증 앱의 코드 또는 복구 코드를 확인해주세요").
+		WithReason("invalid_two_factor_code")
+}
+
+func ErrInvalidTwoFactorChallenge() *AppError {
+	return NewAppError(http.StatusUnauthorized, "2단계 인증 시간이 만료되었습니다", "다시 로그인해주세요").
+		WithReason("invalid_challenge")
+}
+
+func ErrOAuthProviderNotFound() *AppError {
+	return NewAppError(http.StatusNotFound, "지원하지 않는 로그인 제공자입니다", "")
+}
+
+func ErrInvalidOAuthState() *AppError {
+	return NewAppError(http.StatusBadRequest, "소셜 로그인 요청이 만료되었습니다", "로그인을 처음부터 다시 진행해주세요").
+		WithReason("invalid_state")
+}
+
+func ErrOAuthFailed(detail string) *AppError {
+	return NewAppError(http.StatusUnauthorized, "소셜 로그인에 실패했습니다", detail)
+}
+
+func ErrOAuthEmailNotVerified() *AppError {
+	return NewAppError(http.StatusBadRequest, "인증된 이메일이 없습니다", "로그인 제공자에서 이메일 인증을 완료한 뒤 다시 시도해주세요").
+		WithReason("email_not_verified")
+}
+
+func ErrOAuthAccountNotLinkable() *AppError {
+	return NewAppError(http.StatusConflict, "이미 가입된 이메일입니다", "이메일 인증을 마치지 않은 기존 계정과는 연결할 수 없습니다. 비밀번호로 로그인해주세요").
+		WithReason("account_exists")
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	accountService      *services.AccountService
+	loginAttemptService *services.LoginAttemptService
+}
+
+func NewAdminHandler() *AdminHandler {
+	return &AdminHandler{
+		accountService:      services.NewAccountService(),
+		loginAttemptService: services.NewLoginAttemptService(),
+	}
+}
+
+func (h *AdminHandler) ChangeUserRole(c *gin.Context) {
+	// @Summary 사용자 역할 변경
+	// @Description 사용자의 역할(admin, editor, author, reader)을 변경합니다. 대상 사용자의 세션은 모두 종료됩니다
+	// @Tags admin
+	// @Accept json
+	// @Produce json
+	// @Security Bearer
+	// @Param id path uint true "사용자 ID"
+	// @Param request body services.ChangeRoleRequest true "역할 변경 요청"
+	// @Success 200 {object} models.User
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 403 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /admin/users/{id}/role [put]
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "사용자 ID가 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	var req services.ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	user, err := h.accountService.ChangeRole(actor, uint(id), req.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *AdminHandler) GetLoginAttempts(c *gin.Context) {
+	// @Summary 로그인 시도 기록
+	// @Description 비밀번호 로그인 시도 기록을 최신순으로 조회합니다
+	// @Tags admin
+	// @Produce json
+	// @Security Bearer
+	// @Param email query string false "이메일"
+	// @Param ip query string false "IP"
+	// @Param user_id query uint false "사용자 ID"
+	// @Param success query bool false "성공 여부"
+	// @Param last_id query uint false "마지막 기록 ID"
+	// @Param limit query int false "조회할 개수 (기본값: 50)"
+	// @Success 200 {object} models.LoginAttemptListResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 403 {object} map[string]interface{}
+	// @Router /admin/login-attempts [get]
+	var filter services.LoginAttemptFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	attempts, err := h.loginAttemptService.ListLoginAttempts(&filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attempts)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	apiKeyService *services.APIKeyService
+}
+
+func NewAPIKeyHandler() *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: services.NewAPIKeyService(),
+	}
+}
+
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	// @Summary API 키 발급
+	// @Description 자동화에 사용할 API 키를 발급합니다. 키는 이 응답에서만 확인할 수 있습니다
+	// @Tags api-keys
+	// @Accept json
+	// @Produce json
+	// @Security Bearer
+	// @Param request body services.CreateAPIKeyRequest true "API 키 발급 요청"
+	// @Success 201 {object} models.CreatedAPIKeyResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/api-keys [post]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req services.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	key, err := h.apiKeyService.CreateAPIKey(userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	// @Summary API 키 목록
+	// @Description 발급한 API 키 목록을 조회합니다
+	// @Tags api-keys
+	// @Produce json
+	// @Security Bearer
+	// @Success 200 {object} []models.APIKeyResponse
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/api-keys [get]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	// @Summary API 키 폐기
+	// @Description API 키를 폐기합니다. 폐기된 키는 즉시 사용할 수 없습니다
+	// @Tags api-keys
+	// @Produce json
+	// @Security Bearer
+	// @Param id path uint true "API 키 ID"
+	// @Success 204
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /auth/api-keys/{id} [delete]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "API 키 ID가 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(userID, uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"net/url"
 	"portfolio-server/internal/middleware"
 	"portfolio-server/internal/services"
 	"strconv"
@@ -21,17 +22,18 @@ func NewArticleHandler() *ArticleHandler {
 
 func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 	// @Summary 글 작성
-	// @Description 새로운 게시글을 작성합니다
+	// @Description 새로운 게시글을 작성합니다. content는 마크다운이며 저장할 때 HTML로 렌더링됩니다
 	// @Tags articles
 	// @Accept json
 	// @Produce json
 	// @Security Bearer
 	// @Param request body services.CreateArticleRequest true "글 작성 요청"
-	// @Success 201 {object} models.Article
+	// @Success 201 {object} models.ArticleResponse
 	// @Failure 400 {object} map[string]interface{}
 	// @Failure 401 {object} map[string]interface{}
+	// @Failure 403 {object} map[string]interface{}
 	// @Router /articles [post]
-	userID, err := middleware.GetUserIDFromContext(c)
+	actor, err := middleware.GetActorFromContext(c)
 	if err != nil {
 		c.Error(err)
 		return
@@ -47,7 +49,7 @@ func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 		return
 	}
 
-	article, err := h.articleService.CreateArticle(&req, userID)
+	article, err := h.articleService.CreateArticle(&req, actor)
 	if err != nil {
 		c.Error(err)
 		return
@@ -58,10 +60,11 @@ func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 
 func (h *ArticleHandler) GetArticle(c *gin.Context) {
 	// @Summary 글 상세 조회
-	// @Description 특정 게시글의 상세 정보를 조회합니다
+	// @Description 특정 게시글의 상세 정보를 조회합니다. 마크다운 원문(content)과 렌더링된 HTML(content_html), 목차(toc)를 함께 반환합니다. 공개되지 않은 글은 작성자와 editor, admin만 조회할 수 있습니다
 	// @Tags articles
 	// @Accept json
 	// @Produce json
+	// @Security Bearer
 	// @Param id path uint true "글 ID"
 	// @Success 200 {object} models.ArticleResponse
 	// @Failure 400 {object} map[string]interface{}
@@ -78,7 +81,10 @@ func (h *ArticleHandler) GetArticle(c *gin.Context) {
 		return
 	}
 
-	article, err := h.articleService.GetArticleByID(uint(id))
+	// 로그인하지 않은 요청은 빈 Actor로 조회합니다
+	viewer, _ := middleware.GetActorFromContext(c)
+
+	article, err := h.articleService.GetArticleByID(uint(id), viewer, middleware.ClientInfoFromContext(c, ""))
 	if err != nil {
 		c.Error(err)
 		return
@@ -87,46 +93,66 @@ func (h *ArticleHandler) GetArticle(c *gin.Context) {
 	c.JSON(http.StatusOK, article)
 }
 
+func (h *ArticleHandler) GetArticleBySlug(c *gin.Context) {
+	// @Summary 슬러그로 글 조회
+	// @Description 슬러그로 게시글을 조회합니다. 제목이 바뀌기 전의 슬러그로 요청하면 현재 슬러그 주소로 302 리다이렉트합니다. 제목을 되돌리면 예전 슬러그가 다시 쓰이므로 영구 리다이렉트는 쓰지 않습니다
+	// @Tags articles
+	// @Produce json
+	// @Security Bearer
+	// @Param slug path string true "글 슬러그"
+	// @Success 200 {object} models.ArticleResponse
+	// @Success 302
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/by-slug/{slug} [get]
+	viewer, _ := middleware.GetActorFromContext(c)
+
+	article, currentSlug, err := h.articleService.GetArticleBySlug(c.Param("slug"), viewer, middleware.ClientInfoFromContext(c, ""))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if currentSlug != "" {
+		// 예전 슬러그가 나중에 현재 슬러그로 돌아올 수 있어 캐시되는 301 대신 302를 씁니다
+		c.Redirect(http.StatusFound, "/articles/by-slug/"+url.PathEscape(currentSlug))
+		return
+	}
+
+	c.JSON(http.StatusOK, article)
+}
+
 func (h *ArticleHandler) GetArticles(c *gin.Context) {
 	// @Summary 글 목록 조회
-	// @Description 커서 기반 무한 스크롤로 게시글 목록을 조회합니다
+	// @Description 커서 기반 무한 스크롤로 공개된 게시글 목록을 조회합니다. 로그인한 사용자는 status로 자신의 초안, 예약, 보관 글을 조회할 수 있습니다
 	// @Tags articles
 	// @Accept json
 	// @Produce json
-	// @Param last_id query uint false "마지막 글 ID"
+	// @Security Bearer
+	// @Param cursor query string false "이전 응답의 next_cursor"
 	// @Param limit query int false "조회할 개수 (기본값: 20)"
+	// @Param sort query string false "정렬 (newest, oldest, views, comments, 기본값: newest)"
+	// @Param status query string false "글 상태 (draft, published, scheduled, archived)"
+	// @Param category_id query []uint false "카테고리 ID (여러 번 지정하면 그중 하나에 속한 글)"
+	// @Param author_id query uint false "작성자 ID"
+	// @Param from query string false "시작 날짜 (YYYY-MM-DD)"
+	// @Param to query string false "종료 날짜 (YYYY-MM-DD, 포함)"
+	// @Param fields query string false "응답에 포함할 필드 (쉼표로 구분, 예: id,title,excerpt)"
 	// @Success 200 {object} models.ArticleListResponse
 	// @Failure 400 {object} map[string]interface{}
 	// @Router /articles [get]
-	lastIDStr := c.Query("last_id")
-	limitStr := c.DefaultQuery("limit", "20")
-
-	var lastID *uint
-	if lastIDStr != "" {
-		id, err := strconv.ParseUint(lastIDStr, 10, 32)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"code":    http.StatusBadRequest,
-				"message": "last_id 파라미터가 올바르지 않습니다",
-				"detail":  err.Error(),
-			})
-			return
-		}
-		lastIDUint := uint(id)
-		lastID = &lastIDUint
-	}
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
+	var query services.ArticleListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"code":    http.StatusBadRequest,
-			"message": "limit 파라미터가 올바르지 않습니다",
+			"message": "요청 형식이 올바르지 않습니다",
 			"detail":  err.Error(),
 		})
 		return
 	}
 
-	articles, err := h.articleService.GetArticles(lastID, limit)
+	viewer, _ := middleware.GetActorFromContext(c)
+
+	articles, err := h.articleService.GetArticles(&query, viewer)
 	if err != nil {
 		c.Error(err)
 		return
@@ -137,20 +163,20 @@ func (h *ArticleHandler) GetArticles(c *gin.Context) {
 
 func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 	// @Summary 글 수정
-	// @Description 자신의 게시글을 수정합니다
+	// @Description 자신의 게시글을 수정합니다 (editor, admin은 모든 게시글을 수정할 수 있습니다)
 	// @Tags articles
 	// @Accept json
 	// @Produce json
 	// @Security Bearer
 	// @Param id path uint true "글 ID"
 	// @Param request body services.UpdateArticleRequest true "글 수정 요청"
-	// @Success 200 {object} models.Article
+	// @Success 200 {object} models.ArticleResponse
 	// @Failure 400 {object} map[string]interface{}
 	// @Failure 401 {object} map[string]interface{}
 	// @Failure 403 {object} map[string]interface{}
 	// @Failure 404 {object} map[string]interface{}
 	// @Router /articles/{id} [put]
-	userID, err := middleware.GetUserIDFromContext(c)
+	actor, err := middleware.GetActorFromContext(c)
 	if err != nil {
 		c.Error(err)
 		return
@@ -177,7 +203,7 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 		return
 	}
 
-	article, err := h.articleService.UpdateArticle(uint(id), &req, userID)
+	article, err := h.articleService.UpdateArticle(uint(id), &req, actor)
 	if err != nil {
 		c.Error(err)
 		return
@@ -188,7 +214,7 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 
 func (h *ArticleHandler) DeleteArticle(c *gin.Context) {
 	// @Summary 글 삭제
-	// @Description 자신의 게시글을 삭제합니다
+	// @Description 자신의 게시글을 삭제합니다 (editor, admin은 모든 게시글을 삭제할 수 있습니다)
 	// @Tags articles
 	// @Accept json
 	// @Produce json
@@ -200,7 +226,7 @@ func (h *ArticleHandler) DeleteArticle(c *gin.Context) {
 	// @Failure 403 {object} map[string]interface{}
 	// @Failure 404 {object} map[string]interface{}
 	// @Router /articles/{id} [delete]
-	userID, err := middleware.GetUserIDFromContext(c)
+	actor, err := middleware.GetActorFromContext(c)
 	if err != nil {
 		c.Error(err)
 		return
@@ -217,7 +243,7 @@ func (h *ArticleHandler) DeleteArticle(c *gin.Context) {
 		return
 	}
 
-	if err := h.articleService.DeleteArticle(uint(id), userID); err != nil {
+	if err := h.articleService.DeleteArticle(uint(id), actor); err != nil {
 		c.Error(err)
 		return
 	}
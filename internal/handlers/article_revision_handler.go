@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleRevisionHandler struct {
+	revisionService *services.ArticleRevisionService
+}
+
+func NewArticleRevisionHandler() *ArticleRevisionHandler {
+	return &ArticleRevisionHandler{
+		revisionService: services.NewArticleRevisionService(),
+	}
+}
+
+// parseArticleID reads the :id path parameter, writing a 400 response when it is malformed
+func parseArticleID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "게시글 ID가 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseRevision reads a revision number from a path or query value, writing a 400 response when it is malformed
+func parseRevision(c *gin.Context, name, value string) (int, bool) {
+	revision, err := strconv.Atoi(value)
+	if err != nil || revision <= 0 {
+		detail := "버전 번호는 1 이상의 정수여야 합니다"
+		if err != nil {
+			detail = err.Error()
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": name + " 파라미터가 올바르지 않습니다",
+			"detail":  detail,
+		})
+		return 0, false
+	}
+	return revision, true
+}
+
+func (h *ArticleRevisionHandler) GetRevisions(c *gin.Context) {
+	// @Summary 글 버전 목록
+	// @Description 글이 수정될 때마다 저장된 버전을 최신순으로 조회합니다 (본문 제외). 글을 수정할 수 있는 사용자만 조회할 수 있습니다
+	// @Tags articles
+	// @Produce json
+	// @Security Bearer
+	// @Param id path uint true "글 ID"
+	// @Param last_revision query int false "마지막 버전 번호"
+	// @Param limit query int false "조회할 개수 (기본값: 20)"
+	// @Success 200 {object} models.ArticleRevisionListResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 403 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/revisions [get]
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+
+	var lastRevision *int
+	if value := c.Query("last_revision"); value != "" {
+		revision, ok := parseRevision(c, "last_revision", value)
+		if !ok {
+			return
+		}
+		lastRevision = &revision
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "limit 파라미터가 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	revisions, err := h.revisionService.ListRevisions(articleID, lastRevision, limit, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+func (h *ArticleRevisionHandler) GetRevision(c *gin.Context) {
+	// @Summary 글 버전 조회
+	// @Description 특정 버전의 제목과 본문을 조회합니다
+	// @Tags articles
+	// @Produce json
+	// @Security Bearer
+	// @Param id path uint true "글 ID"
+	// @Param rev path int true "버전 번호"
+	// @Success 200 {object} models.ArticleRevisionResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 403 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/revisions/{rev} [get]
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+	revision, ok := parseRevision(c, "rev", c.Param("rev"))
+	if !ok {
+		return
+	}
+
+	response, err := h.revisionService.GetRevision(articleID, revision, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ArticleRevisionHandler) DiffRevisions(c *gin.Context) {
+	// @Summary 글 버전 비교
+	// @Description 두 버전의 본문을 줄 단위로 비교합니다
+	// @Tags articles
+	// @Produce json
+	// @Security Bearer
+	// @Param id path uint true "글 ID"
+	// @Param from query int true "기준 버전 번호"
+	// @Param to query int true "비교할 버전 번호"
+	// @Success 200 {object} models.ArticleRevisionDiffResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 403 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/revisions/diff [get]
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+	from, ok := parseRevision(c, "from", c.Query("from"))
+	if !ok {
+		return
+	}
+	to, ok := parseRevision(c, "to", c.Query("to"))
+	if !ok {
+		return
+	}
+
+	diff, err := h.revisionService.DiffRevisions(articleID, from, to, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+func (h *ArticleRevisionHandler) RestoreRevision(c *gin.Context) {
+	// @Summary 글 버전 복원
+	// @Description 글의 제목과 본문을 특정 버전으로 되돌립니다. 복원도 새 버전으로 기록됩니다
+	// @Tags articles
+	// @Produce json
+	// @Security Bearer
+	// @Param id path uint true "글 ID"
+	// @Param rev path int true "복원할 버전 번호"
+	// @Success 200 {object} models.ArticleResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 403 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/revisions/{rev}/restore [post]
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+	revision, ok := parseRevision(c, "rev", c.Param("rev"))
+	if !ok {
+		return
+	}
+
+	article, err := h.revisionService.RestoreRevision(articleID, revision, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, article)
+}
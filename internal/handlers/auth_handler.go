@@ -9,18 +9,28 @@ import (
 )
 
 type AuthHandler struct {
-	authService *services.AuthService
+	authService     *services.AuthService
+	tokenService    *services.TokenService
+	passwordService *services.PasswordService
+	accountService  *services.AccountService
+	oauthService    *services.OAuthService
+	magicLink       *services.MagicLinkService
 }
 
 func NewAuthHandler() *AuthHandler {
 	return &AuthHandler{
-		authService: services.NewAuthService(),
+		authService:     services.NewAuthService(),
+		tokenService:    services.NewTokenService(),
+		passwordService: services.NewPasswordService(),
+		accountService:  services.NewAccountService(),
+		oauthService:    services.NewOAuthService(),
+		magicLink:       services.NewMagicLinkService(),
 	}
 }
 
 func (h *AuthHandler) Register(c *gin.Context) {
 	// @Summary 사용자 회원가입
-	// @Description 새로운 사용자를 등록합니다
+	// @Description 이메일 인증 후 발급된 인증 토큰으로 새로운 사용자를 등록합니다
 	// @Tags auth
 	// @Accept json
 	// @Produce json
@@ -39,7 +49,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Register(&req)
+	response, err := h.authService.Register(&req, middleware.ClientInfoFromContext(c, req.DeviceName))
 	if err != nil {
 		c.Error(err)
 		return
@@ -69,7 +79,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Login(&req)
+	response, err := h.authService.Login(&req, middleware.ClientInfoFromContext(c, req.DeviceName))
 	if err != nil {
 		c.Error(err)
 		return
@@ -78,6 +88,143 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	// @Summary 토큰 갱신
+	// @Description Refresh Token을 교체하고 새 Access Token을 발급합니다. 이미 사용된 Refresh Token이 다시 제출되면 세션이 종료됩니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Param request body services.RefreshTokenRequest true "토큰 갱신 요청"
+	// @Success 200 {object} services.AuthResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/refresh [post]
+	var req services.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	response, err := h.tokenService.Refresh(req.RefreshToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	// @Summary 로그아웃
+	// @Description 현재 세션을 종료합니다. 이 세션의 Access/Refresh Token은 더 이상 사용할 수 없습니다
+	// @Tags auth
+	// @Produce json
+	// @Security Bearer
+	// @Success 204
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/logout [post]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sessionID, err := middleware.GetSessionIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.tokenService.Logout(userID, sessionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	// @Summary 모든 기기에서 로그아웃
+	// @Description 사용자의 모든 세션을 종료합니다
+	// @Tags auth
+	// @Produce json
+	// @Security Bearer
+	// @Success 204
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/logout-all [post]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.tokenService.LogoutAll(userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AuthHandler) GetSessions(c *gin.Context) {
+	// @Summary 로그인 세션 목록
+	// @Description 로그인된 기기 목록을 최근 사용 순으로 조회합니다. 현재 요청한 세션은 current가 true입니다
+	// @Tags auth
+	// @Produce json
+	// @Security Bearer
+	// @Success 200 {object} []middleware.SessionInfo
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/sessions [get]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sessionID, err := middleware.GetSessionIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sessions, err := h.tokenService.ListSessions(userID, sessionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	// @Summary 세션 종료
+	// @Description 지정한 기기의 세션을 종료합니다
+	// @Tags auth
+	// @Produce json
+	// @Security Bearer
+	// @Param id path string true "세션 ID"
+	// @Success 204
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /auth/sessions/{id} [delete]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.tokenService.RevokeSession(userID, c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func (h *AuthHandler) GetProfile(c *gin.Context) {
 	// @Summary 프로필 조회
 	// @Description 현재 로그인한 사용자의 프로필을 조회합니다
@@ -113,6 +260,7 @@ func (h *AuthHandler) SendVerificationCode(c *gin.Context) {
 	// @Success 200 {object} map[string]interface{}
 	// @Failure 400 {object} map[string]interface{}
 	// @Failure 409 {object} map[string]interface{}
+	// @Failure 429 {object} map[string]interface{}
 	// @Router /auth/send-verification-code [post]
 	var req services.SendVerificationCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -124,7 +272,7 @@ func (h *AuthHandler) SendVerificationCode(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.SendVerificationCode(req.Email); err != nil {
+	if err := h.authService.SendVerificationCode(req.Email, c.ClientIP()); err != nil {
 		c.Error(err)
 		return
 	}
@@ -136,13 +284,14 @@ func (h *AuthHandler) SendVerificationCode(c *gin.Context) {
 
 func (h *AuthHandler) VerifyCode(c *gin.Context) {
 	// @Summary 이메일 인증 코드 검증
-	// @Description 이메일로 전송된 인증 코드를 검증합니다
+	// @Description 이메일로 전송된 인증 코드를 검증하고 회원가입에 사용할 1회용 인증 토큰을 발급합니다
 	// @Tags auth
 	// @Accept json
 	// @Produce json
 	// @Param request body services.VerifyCodeRequest true "인증 코드 검증 요청"
-	// @Success 200 {object} map[string]interface{}
+	// @Success 200 {object} services.VerifyCodeResponse
 	// @Failure 400 {object} map[string]interface{}
+	// @Failure 429 {object} map[string]interface{}
 	// @Router /auth/verify-code [post]
 	var req services.VerifyCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -154,12 +303,376 @@ func (h *AuthHandler) VerifyCode(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.VerifyCode(req.Email, req.Code); err != nil {
+	response, err := h.authService.VerifyCode(req.Email, req.Code, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	// @Summary 비밀번호 재설정 메일 요청
+	// @Description 가입된 이메일이면 비밀번호 재설정 링크를 전송합니다. 가입 여부와 관계없이 같은 응답을 반환합니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Param request body services.ForgotPasswordRequest true "비밀번호 재설정 메일 요청"
+	// @Success 202 {object} map[string]interface{}
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 429 {object} map[string]interface{}
+	// @Router /auth/password/forgot [post]
+	var req services.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	if err := h.passwordService.ForgotPassword(req.Email, c.ClientIP()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "가입된 이메일이라면 비밀번호 재설정 링크가 전송됩니다",
+	})
+}
+
+func (h *AuthHandler) SendMagicLink(c *gin.Context) {
+	// @Summary 로그인 링크 요청
+	// @Description 가입된 이메일이면 비밀번호 없이 로그인할 수 있는 일회용 링크를 전송합니다. 가입 여부와 관계없이 같은 응답을 반환합니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Param request body services.MagicLinkRequest true "로그인 링크 요청"
+	// @Success 202 {object} map[string]interface{}
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 429 {object} map[string]interface{}
+	// @Router /auth/magic-link [post]
+	var req services.MagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	if err := h.magicLink.SendMagicLink(req.Email, c.ClientIP()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "가입된 이메일이라면 로그인 링크가 전송됩니다",
+	})
+}
+
+func (h *AuthHandler) VerifyMagicLink(c *gin.Context) {
+	// @Summary 로그인 링크로 로그인
+	// @Description 메일로 받은 로그인 링크의 토큰으로 로그인합니다. 2단계 인증이 켜져 있으면 challenge_token을 반환합니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Param request body services.VerifyMagicLinkRequest true "로그인 링크 확인 요청"
+	// @Success 200 {object} services.AuthResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 429 {object} map[string]interface{}
+	// @Router /auth/magic-link/verify [post]
+	var req services.VerifyMagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	response, err := h.magicLink.VerifyMagicLink(req.Token, middleware.ClientInfoFromContext(c, req.DeviceName))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	// @Summary 비밀번호 재설정
+	// @Description 메일로 받은 토큰으로 새 비밀번호를 설정합니다. 기존 세션은 모두 종료됩니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Param request body services.ResetPasswordRequest true "비밀번호 재설정 요청"
+	// @Success 200 {object} map[string]interface{}
+	// @Failure 400 {object} map[string]interface{}
+	// @Router /auth/password/reset [post]
+	var req services.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	if err := h.passwordService.ResetPassword(req.Token, req.NewPassword); err != nil {
 		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "이메일 인증이 완료되었습니다",
+		"message": "비밀번호가 변경되었습니다. 다시 로그인해주세요",
 	})
 }
+
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	// @Summary 비밀번호 변경
+	// @Description 현재 비밀번호를 확인한 뒤 새 비밀번호로 변경합니다. 비밀번호가 없는 계정(소셜 로그인 가입)은 POST /auth/reauth로 받은 code를 보냅니다. 현재 세션을 제외한 모든 세션이 종료됩니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Security Bearer
+	// @Param request body services.ChangePasswordRequest true "비밀번호 변경 요청"
+	// @Success 200 {object} map[string]interface{}
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/password [put]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sessionID, err := middleware.GetSessionIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req services.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	if err := h.accountService.ChangePassword(userID, sessionID, &req, c.ClientIP()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "비밀번호가 변경되었습니다",
+	})
+}
+
+func (h *AuthHandler) RequestEmailChange(c *gin.Context) {
+	// @Summary 이메일 변경 요청
+	// @Description 새 이메일 주소로 인증 코드를 전송합니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Security Bearer
+	// @Param request body services.ChangeEmailRequest true "이메일 변경 요청"
+	// @Success 200 {object} map[string]interface{}
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 409 {object} map[string]interface{}
+	// @Failure 429 {object} map[string]interface{}
+	// @Router /auth/email [post]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req services.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	if err := h.accountService.RequestEmailChange(userID, req.NewEmail, c.ClientIP()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "인증 코드가 성공적으로 전송되었습니다",
+	})
+}
+
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	// @Summary 이메일 변경 확인
+	// @Description 새 이메일로 받은 인증 코드를 확인하고 이메일을 변경합니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Security Bearer
+	// @Param request body services.ConfirmEmailChangeRequest true "이메일 변경 확인 요청"
+	// @Success 200 {object} models.User
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 409 {object} map[string]interface{}
+	// @Router /auth/email/confirm [post]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req services.ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	user, err := h.accountService.ConfirmEmailChange(userID, &req, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *AuthHandler) RequestReauthCode(c *gin.Context) {
+	// @Summary 본인 확인 코드 요청
+	// @Description 계정 이메일로 인증 코드를 보냅니다. 비밀번호가 없는 계정이 비밀번호 변경이나 회원 탈퇴를 할 때 사용합니다
+	// @Tags auth
+	// @Produce json
+	// @Security Bearer
+	// @Success 200 {object} map[string]interface{}
+	// @Failure 429 {object} map[string]interface{}
+	// @Router /auth/reauth [post]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.accountService.RequestReauthCode(userID, c.ClientIP()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "인증 코드가 성공적으로 전송되었습니다",
+	})
+}
+
+func (h *AuthHandler) DeleteAccount(c *gin.Context) {
+	// @Summary 회원 탈퇴
+	// @Description 비밀번호(비밀번호가 없는 계정은 POST /auth/reauth로 받은 code)를 확인한 뒤 계정을 삭제합니다. 작성한 글과 댓글은 서버 설정에 따라 익명화되거나 함께 삭제됩니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Security Bearer
+	// @Param request body services.DeleteAccountRequest true "회원 탈퇴 요청"
+	// @Success 204
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/account [delete]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req services.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	if err := h.accountService.DeleteAccount(userID, &req, c.ClientIP()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AuthHandler) GetOAuthProviders(c *gin.Context) {
+	// @Summary 소셜 로그인 제공자 목록
+	// @Description 사용할 수 있는 소셜 로그인 제공자 이름을 반환합니다
+	// @Tags auth
+	// @Produce json
+	// @Success 200 {object} map[string]interface{}
+	// @Router /auth/oauth/providers [get]
+	c.JSON(http.StatusOK, gin.H{
+		"providers": h.oauthService.ListProviders(),
+	})
+}
+
+func (h *AuthHandler) OAuthAuthorize(c *gin.Context) {
+	// @Summary 소셜 로그인 시작
+	// @Description 로그인 제공자의 인증 URL과 state를 발급합니다. 클라이언트는 state를 보관한 뒤 authorization_url로 이동합니다
+	// @Tags auth
+	// @Produce json
+	// @Param provider path string true "로그인 제공자 (github, google 등)"
+	// @Success 200 {object} services.OAuthAuthorizeResponse
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /auth/oauth/{provider}/authorize [get]
+	response, err := h.oauthService.Authorize(c.Param("provider"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AuthHandler) OAuthCallback(c *gin.Context) {
+	// @Summary 소셜 로그인 완료
+	// @Description 로그인 제공자가 돌려준 code와 state로 로그인합니다. 같은 이메일로 인증된 계정이 있으면 연결하고, 없으면 새로 가입합니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Param provider path string true "로그인 제공자 (github, google 등)"
+	// @Param request body services.OAuthCallbackRequest true "소셜 로그인 콜백 요청"
+	// @Success 200 {object} services.AuthResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 409 {object} map[string]interface{}
+	// @Router /auth/oauth/{provider}/callback [post]
+	var req services.OAuthCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	response, err := h.oauthService.Callback(c.Param("provider"), &req, middleware.ClientInfoFromContext(c, req.DeviceName))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookmarkHandler struct {
+	bookmarkService *services.BookmarkService
+}
+
+func NewBookmarkHandler() *BookmarkHandler {
+	return &BookmarkHandler{
+		bookmarkService: services.NewBookmarkService(),
+	}
+}
+
+func (h *BookmarkHandler) AddBookmark(c *gin.Context) {
+	// @Summary 북마크 추가
+	// @Description 글을 읽기 목록에 담습니다. 이미 담긴 글이면 처음 담은 시각을 그대로 돌려줍니다
+	// @Tags bookmarks
+	// @Produce json
+	// @Security Bearer
+	// @Param id path int true "게시글 ID"
+	// @Success 201 {object} models.BookmarkResponse
+	// @Success 200 {object} models.BookmarkResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/bookmark [post]
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	bookmark, created, err := h.bookmarkService.AddBookmark(articleID, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, bookmark)
+}
+
+func (h *BookmarkHandler) RemoveBookmark(c *gin.Context) {
+	// @Summary 북마크 삭제
+	// @Description 글을 읽기 목록에서 뺍니다. 담겨 있지 않은 글이어도 성공합니다
+	// @Tags bookmarks
+	// @Security Bearer
+	// @Param id path int true "게시글 ID"
+	// @Success 204
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /articles/{id}/bookmark [delete]
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.bookmarkService.RemoveBookmark(articleID, actor); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BookmarkHandler) GetMyBookmarks(c *gin.Context) {
+	// @Summary 내 북마크 목록
+	// @Description 읽기 목록에 담은 글을 최근에 담은 순서로 조회합니다. 삭제되었거나 볼 수 없게 된 글은 빠집니다
+	// @Tags bookmarks
+	// @Produce json
+	// @Security Bearer
+	// @Param cursor query string false "이전 응답의 next_cursor"
+	// @Param limit query int false "조회할 개수 (기본값: 20)"
+	// @Param fields query string false "응답에 포함할 필드 (쉼표로 구분, 예: id,title,excerpt)"
+	// @Success 200 {object} models.ArticleListResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /me/bookmarks [get]
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var query services.BookmarkListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	bookmarks, err := h.bookmarkService.GetBookmarks(&query, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bookmarks)
+}
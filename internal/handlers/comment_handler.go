@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"portfolio-server/internal/middleware"
 	"portfolio-server/internal/services"
 	"strconv"
 
@@ -77,7 +78,10 @@ func (h *CommentHandler) GetComments(c *gin.Context) {
 		}
 	}
 
-	comments, err := h.commentService.GetCommentsByArticleID(uint(articleID), lastID, limit)
+	// 로그인하지 않은 요청은 빈 Actor로 조회합니다
+	viewer, _ := middleware.GetActorFromContext(c)
+
+	comments, err := h.commentService.GetCommentsByArticleID(uint(articleID), lastID, limit, viewer)
 	if err != nil {
 		c.Error(err)
 		return
@@ -108,8 +112,13 @@ func (h *CommentHandler) UpdateComment(c *gin.Context) {
 		return
 	}
 
-	userID := c.GetUint("user_id")
-	comment, err := h.commentService.UpdateComment(uint(commentID), &req, userID)
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(uint(commentID), &req, actor)
 	if err != nil {
 		c.Error(err)
 		return
@@ -130,8 +139,13 @@ func (h *CommentHandler) DeleteComment(c *gin.Context) {
 		return
 	}
 
-	userID := c.GetUint("user_id")
-	if err := h.commentService.DeleteComment(uint(commentID), userID); err != nil {
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.commentService.DeleteComment(uint(commentID), actor); err != nil {
 		c.Error(err)
 		return
 	}
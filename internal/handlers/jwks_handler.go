@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJWKS publishes the public keys that access tokens are signed with so other services can verify them
+func GetJWKS(c *gin.Context) {
+	// @Summary JWKS
+	// @Description Access Token 검증에 사용할 공개 키 목록을 반환합니다 (RS256/EdDSA 사용 시)
+	// @Tags auth
+	// @Produce json
+	// @Success 200 {object} middleware.JWKS
+	// @Router /.well-known/jwks.json [get]
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, middleware.PublicJWKS())
+}
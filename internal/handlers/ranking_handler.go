@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RankingHandler struct {
+	rankingService *services.RankingService
+}
+
+func NewRankingHandler() *RankingHandler {
+	return &RankingHandler{
+		rankingService: services.NewRankingService(),
+	}
+}
+
+func (h *RankingHandler) GetTrending(c *gin.Context) {
+	// @Summary 인기 글
+	// @Description 최근 조회수, 댓글, 반응에 시간 감쇠를 적용한 점수가 높은 공개 글을 조회합니다. 순위는 주기적으로 다시 계산됩니다
+	// @Tags articles
+	// @Produce json
+	// @Param limit query int false "조회할 개수 (기본값, 최대값: RANKING_SIZE)"
+	// @Success 200 {object} models.RankingResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Router /articles/trending [get]
+	var query services.RankingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	ranking, err := h.rankingService.Trending(&query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ranking)
+}
+
+func (h *RankingHandler) GetLeaderboard(c *gin.Context) {
+	// @Summary 기간별 조회수 순위
+	// @Description 기간(day, week, month, all) 동안 조회수가 가장 많은 공개 글을 조회합니다. 순위는 주기적으로 다시 계산됩니다
+	// @Tags articles
+	// @Produce json
+	// @Param period query string false "기간 (day, week, month, all, 기본값: week)"
+	// @Param limit query int false "조회할 개수 (기본값, 최대값: RANKING_SIZE)"
+	// @Success 200 {object} models.RankingResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Router /articles/leaderboard [get]
+	var query services.RankingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	ranking, err := h.rankingService.Leaderboard(&query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ranking)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+	"portfolio-server/internal/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReactionHandler struct {
+	reactionService *services.ReactionService
+}
+
+func NewReactionHandler() *ReactionHandler {
+	return &ReactionHandler{
+		reactionService: services.NewReactionService(),
+	}
+}
+
+// reactionFunc adds or removes a reaction on the target of the request
+type reactionFunc func(reaction models.ReactionType, actor policy.Actor) (*models.ReactionSummary, error)
+
+// parseReaction reads the reaction type from the path
+func parseReaction(c *gin.Context) (models.ReactionType, bool) {
+	reaction := models.ReactionType(c.Param("type"))
+	if !reaction.IsValid() {
+		c.Error(errors.ErrInvalidInput("반응은 like, love, laugh, wow, sad, celebrate 중 하나여야 합니다"))
+		return "", false
+	}
+	return reaction, true
+}
+
+func (h *ReactionHandler) react(c *gin.Context, apply reactionFunc) {
+	reaction, ok := parseReaction(c)
+	if !ok {
+		return
+	}
+
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	summary, err := apply(reaction, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (h *ReactionHandler) AddArticleReaction(c *gin.Context) {
+	// @Summary 글에 반응 남기기
+	// @Description 글에 반응을 남깁니다. 이미 남긴 반응이면 아무것도 바뀌지 않습니다
+	// @Tags reactions
+	// @Produce json
+	// @Security Bearer
+	// @Param id path int true "게시글 ID"
+	// @Param type path string true "반응 (like, love, laugh, wow, sad, celebrate)"
+	// @Success 200 {object} models.ReactionSummary
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/reactions/{type} [put]
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+	h.react(c, func(reaction models.ReactionType, actor policy.Actor) (*models.ReactionSummary, error) {
+		return h.reactionService.AddArticleReaction(articleID, reaction, actor)
+	})
+}
+
+func (h *ReactionHandler) RemoveArticleReaction(c *gin.Context) {
+	// @Summary 글의 반응 취소
+	// @Description 글에 남긴 반응을 취소합니다. 남기지 않은 반응이어도 성공합니다
+	// @Tags reactions
+	// @Produce json
+	// @Security Bearer
+	// @Param id path int true "게시글 ID"
+	// @Param type path string true "반응 (like, love, laugh, wow, sad, celebrate)"
+	// @Success 200 {object} models.ReactionSummary
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/reactions/{type} [delete]
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+	h.react(c, func(reaction models.ReactionType, actor policy.Actor) (*models.ReactionSummary, error) {
+		return h.reactionService.RemoveArticleReaction(articleID, reaction, actor)
+	})
+}
+
+func (h *ReactionHandler) AddCommentReaction(c *gin.Context) {
+	// @Summary 댓글에 반응 남기기
+	// @Description 댓글에 반응을 남깁니다. 이미 남긴 반응이면 아무것도 바뀌지 않습니다
+	// @Tags reactions
+	// @Produce json
+	// @Security Bearer
+	// @Param id path int true "게시글 ID"
+	// @Param commentId path int true "댓글 ID"
+	// @Param type path string true "반응 (like, love, laugh, wow, sad, celebrate)"
+	// @Success 200 {object} models.ReactionSummary
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/comments/{commentId}/reactions/{type} [put]
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+	commentID, ok := parseCommentID(c)
+	if !ok {
+		return
+	}
+	h.react(c, func(reaction models.ReactionType, actor policy.Actor) (*models.ReactionSummary, error) {
+		return h.reactionService.AddCommentReaction(articleID, commentID, reaction, actor)
+	})
+}
+
+func (h *ReactionHandler) RemoveCommentReaction(c *gin.Context) {
+	// @Summary 댓글의 반응 취소
+	// @Description 댓글에 남긴 반응을 취소합니다. 남기지 않은 반응이어도 성공합니다
+	// @Tags reactions
+	// @Produce json
+	// @Security Bearer
+	// @Param id path int true "게시글 ID"
+	// @Param commentId path int true "댓글 ID"
+	// @Param type path string true "반응 (like, love, laugh, wow, sad, celebrate)"
+	// @Success 200 {object} models.ReactionSummary
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/comments/{commentId}/reactions/{type} [delete]
+	articleID, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+	commentID, ok := parseCommentID(c)
+	if !ok {
+		return
+	}
+	h.react(c, func(reaction models.ReactionType, actor policy.Actor) (*models.ReactionSummary, error) {
+		return h.reactionService.RemoveCommentReaction(articleID, commentID, reaction, actor)
+	})
+}
+
+// parseCommentID reads the comment ID from the path, writing a 400 response when it is malformed
+func parseCommentID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "댓글 ID가 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchService *services.SearchService
+}
+
+func NewSearchHandler() *SearchHandler {
+	return &SearchHandler{
+		searchService: services.NewSearchService(),
+	}
+}
+
+func (h *SearchHandler) Search(c *gin.Context) {
+	// @Summary 검색
+	// @Description 공개된 글과 댓글을 전문 검색합니다. 관련도순으로 정렬되며 일치한 부분은 snippet에 <mark>로 표시됩니다
+	// @Tags search
+	// @Produce json
+	// @Param q query string true "검색어 (\"정확한 구문\", -제외어, or 지원)"
+	// @Param type query string false "검색 대상 (all, articles, comments, 기본값: all)"
+	// @Param category_id query uint false "카테고리 ID"
+	// @Param author_id query uint false "작성자 ID"
+	// @Param from query string false "시작 날짜 (YYYY-MM-DD)"
+	// @Param to query string false "종료 날짜 (YYYY-MM-DD, 포함)"
+	// @Param limit query int false "조회할 개수 (기본값: 20)"
+	// @Param offset query int false "건너뛸 개수"
+	// @Success 200 {object} models.SearchResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Router /search [get]
+	var req services.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	results, err := h.searchService.Search(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatsHandler struct {
+	statsService *services.StatsService
+}
+
+func NewStatsHandler() *StatsHandler {
+	return &StatsHandler{
+		statsService: services.NewStatsService(),
+	}
+}
+
+func (h *StatsHandler) GetArticleStats(c *gin.Context) {
+	// @Summary 글 통계
+	// @Description 글의 일별 조회수, 순 방문자 수와 유입 경로를 조회합니다. 작성자와 editor, admin만 조회할 수 있습니다
+	// @Tags stats
+	// @Produce json
+	// @Security Bearer
+	// @Param id path uint true "글 ID"
+	// @Param from query string false "시작 날짜 (YYYY-MM-DD, 기본값: 29일 전)"
+	// @Param to query string false "종료 날짜 (YYYY-MM-DD, 포함, 기본값: 오늘)"
+	// @Success 200 {object} models.ArticleStatsResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 403 {object} map[string]interface{}
+	// @Failure 404 {object} map[string]interface{}
+	// @Router /articles/{id}/stats [get]
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	id, ok := parseArticleID(c)
+	if !ok {
+		return
+	}
+
+	var query services.StatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	stats, err := h.statsService.GetArticleStats(id, &query, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *StatsHandler) GetMyStats(c *gin.Context) {
+	// @Summary 내 글 통계
+	// @Description 내가 쓴 모든 글의 누적 조회수, 댓글 수와 기간 중 일별 조회수, 조회수가 많은 글을 조회합니다
+	// @Tags stats
+	// @Produce json
+	// @Security Bearer
+	// @Param from query string false "시작 날짜 (YYYY-MM-DD, 기본값: 29일 전)"
+	// @Param to query string false "종료 날짜 (YYYY-MM-DD, 포함, 기본값: 오늘)"
+	// @Success 200 {object} models.AuthorStatsResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /me/stats [get]
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var query services.StatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	stats, err := h.statsService.GetAuthorStats(&query, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService *services.TwoFactorService
+}
+
+func NewTwoFactorHandler() *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: services.NewTwoFactorService(),
+	}
+}
+
+func (h *TwoFactorHandler) CompleteLogin(c *gin.Context) {
+	// @Summary 2단계 인증 로그인
+	// @Description 로그인 시 받은 challenge_token과 TOTP 코드 또는 복구 코드로 로그인을 완료합니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Param request body services.TwoFactorLoginRequest true "2단계 인증 요청"
+	// @Success 200 {object} services.AuthResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 429 {object} map[string]interface{}
+	// @Router /auth/login/2fa [post]
+	var req services.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	response, err := h.twoFactorService.CompleteLogin(&req, middleware.ClientInfoFromContext(c, ""))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TwoFactorHandler) Setup(c *gin.Context) {
+	// @Summary 2단계 인증 등록 시작
+	// @Description 인증 앱에 등록할 TOTP 키를 발급합니다. confirm 요청으로 첫 코드를 확인해야 활성화됩니다
+	// @Tags auth
+	// @Produce json
+	// @Security Bearer
+	// @Success 200 {object} services.TwoFactorSetupResponse
+	// @Failure 401 {object} map[string]interface{}
+	// @Failure 409 {object} map[string]interface{}
+	// @Router /auth/2fa/setup [post]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.twoFactorService.Setup(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TwoFactorHandler) Confirm(c *gin.Context) {
+	// @Summary 2단계 인증 활성화
+	// @Description 인증 앱의 코드를 확인하고 2단계 인증을 활성화합니다. 복구 코드는 이 응답에서만 확인할 수 있습니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Security Bearer
+	// @Param request body services.ConfirmTwoFactorRequest true "2단계 인증 활성화 요청"
+	// @Success 200 {object} services.RecoveryCodesResponse
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/2fa/confirm [post]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req services.ConfirmTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	response, err := h.twoFactorService.Confirm(userID, req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	// @Summary 2단계 인증 해제
+	// @Description 비밀번호와 TOTP 코드 또는 복구 코드를 확인한 뒤 2단계 인증을 해제합니다
+	// @Tags auth
+	// @Accept json
+	// @Produce json
+	// @Security Bearer
+	// @Param request body services.DisableTwoFactorRequest true "2단계 인증 해제 요청"
+	// @Success 200 {object} map[string]interface{}
+	// @Failure 400 {object} map[string]interface{}
+	// @Failure 401 {object} map[string]interface{}
+	// @Router /auth/2fa/disable [post]
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req services.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    http.StatusBadRequest,
+			"message": "요청 형식이 올바르지 않습니다",
+			"detail":  err.Error(),
+		})
+		return
+	}
+
+	if err := h.twoFactorService.Disable(userID, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "2단계 인증이 해제되었습니다",
+	})
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
+	"portfolio-server/internal/middleware"
 	"portfolio-server/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -65,8 +67,14 @@ func (h *UploadHandler) UploadImage(c *gin.Context) {
 		return
 	}
 
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	// 업로드 처리
-	result, err := h.uploadService.UploadImage(c.Request.Context(), file)
+	result, err := h.uploadService.UploadImage(c.Request.Context(), file, userID)
 	if err != nil {
 		log.Printf("Upload error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -84,13 +92,15 @@ func (h *UploadHandler) UploadImage(c *gin.Context) {
 
 // DeleteImage godoc
 // @Summary 이미지 삭제
-// @Description MinIO에서 이미지를 삭제합니다
+// @Description MinIO에서 이미지를 삭제합니다. 업로드한 사용자 또는 관리자만 삭제할 수 있습니다
 // @Tags upload
 // @Accept json
 // @Produce json
 // @Param fileName query string true "삭제할 파일명"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /upload/image [delete]
 func (h *UploadHandler) DeleteImage(c *gin.Context) {
@@ -102,7 +112,25 @@ func (h *UploadHandler) DeleteImage(c *gin.Context) {
 		return
 	}
 
-	err := h.uploadService.DeleteImage(c.Request.Context(), fileName)
+	actor, err := middleware.GetActorFromContext(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	err = h.uploadService.DeleteImage(c.Request.Context(), fileName, actor)
+	if errors.Is(err, services.ErrUploadNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "이미지를 찾을 수 없습니다",
+		})
+		return
+	}
+	if errors.Is(err, services.ErrUploadForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "이미지를 삭제할 권한이 없습니다",
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("Delete error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
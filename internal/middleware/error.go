@@ -4,14 +4,17 @@ import (
 	"log"
 	"net/http"
 	"portfolio-server/internal/errors"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Detail  string `json:"detail,omitempty"`
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+	Detail     string `json:"detail,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	RetryAfter int    `json:"retry_after,omitempty"`
 }
 
 func ErrorHandler() gin.HandlerFunc {
@@ -22,10 +25,15 @@ func ErrorHandler() gin.HandlerFunc {
 			err := c.Errors.Last().Err
 
 			if appErr, ok := err.(*errors.AppError); ok {
+				if appErr.RetryAfter > 0 {
+					c.Header("Retry-After", strconv.Itoa(appErr.RetryAfter))
+				}
 				c.JSON(appErr.Code, ErrorResponse{
-					Code:    appErr.Code,
-					Message: appErr.Message,
-					Detail:  appErr.Detail,
+					Code:       appErr.Code,
+					Message:    appErr.Message,
+					Detail:     appErr.Detail,
+					Reason:     appErr.Reason,
+					RetryAfter: appErr.RetryAfter,
 				})
 				return
 			}
@@ -61,7 +69,7 @@ func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, accept, origin, Cache-Control, X-Requested-With")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
 		if c.Request.Method == "OPTIONS" {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"portfolio-server/internal/config"
+	"portfolio-server/internal/models"
 	"strings"
 	"time"
 
@@ -12,28 +13,51 @@ import (
 )
 
 type Claims struct {
-	UserID   uint   `json:"user_id"`
-	Email    string `json:"email"`
-	Username string `json:"username"`
+	UserID    uint        `json:"user_id"`
+	Email     string      `json:"email"`
+	Username  string      `json:"username"`
+	Role      models.Role `json:"role"`
+	SessionID string      `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-var jwtConfig *config.JWTConfig
+var (
+	jwtConfig *config.JWTConfig
+	keys      *keySet
+)
 
-func InitJWT(cfg *config.JWTConfig) {
+// InitJWT loads the signing and verification keys. In production it refuses to start
+// with the default or a short HMAC secret.
+func InitJWT(cfg *config.JWTConfig, env string) error {
+	ks, err := loadKeySet(cfg, env)
+	if err != nil {
+		return err
+	}
 	jwtConfig = cfg
+	keys = ks
+	return nil
+}
+
+// AccessTokenTTL returns the lifetime of an access token
+func AccessTokenTTL() time.Duration {
+	if jwtConfig == nil {
+		return 0
+	}
+	return time.Duration(jwtConfig.AccessExpirationMinutes) * time.Minute
 }
 
-func GenerateToken(userID uint, email, username string) (string, error) {
+func GenerateToken(user *models.User, sessionID string) (string, error) {
 	if jwtConfig == nil {
 		return "", fmt.Errorf("JWT config not initialized")
 	}
 
-	expirationTime := time.Now().Add(time.Duration(jwtConfig.ExpirationHours) * time.Hour)
+	expirationTime := time.Now().Add(AccessTokenTTL())
 	claims := &Claims{
-		UserID:   userID,
-		Email:    email,
-		Username: username,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Username:  user.Username,
+		Role:      user.Role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -41,8 +65,11 @@ func GenerateToken(userID uint, email, username string) (string, error) {
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(jwtConfig.Secret))
+	token := jwt.NewWithClaims(keys.method, claims)
+	if keys.kid != "" {
+		token.Header["kid"] = keys.kid
+	}
+	tokenString, err := token.SignedString(keys.signingKey)
 	if err != nil {
 		return "", err
 	}
@@ -56,12 +83,7 @@ func ValidateToken(tokenString string) (*Claims, error) {
 	}
 
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(jwtConfig.Secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, keys.keyFunc)
 
 	if err != nil {
 		return nil, err
@@ -74,40 +96,78 @@ func ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
-func AuthMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "인증 헤더가 필요합니다",
-			})
-			c.Abort()
-			return
-		}
+// authError is why a request could not be authenticated
+type authError struct {
+	status  int
+	message string
+}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "인증 헤더 형식이 올바르지 않습니다",
-			})
-			c.Abort()
-			return
-		}
+func (e *authError) abort(c *gin.Context) {
+	c.JSON(e.status, gin.H{
+		"error": e.message,
+	})
+	c.Abort()
+}
+
+// authenticate checks the API key or access token of the request and stores the user in the context
+func authenticate(c *gin.Context, scopes []string) *authError {
+	if apiKey := extractAPIKey(c); apiKey != "" {
+		return authenticateAPIKey(c, apiKey, scopes)
+	}
+
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return &authError{http.StatusUnauthorized, "인증 헤더가 필요합니다"}
+	}
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return &authError{http.StatusUnauthorized, "인증 헤더 형식이 올바르지 않습니다"}
+	}
 
-		tokenString := parts[1]
-		claims, err := ValidateToken(tokenString)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "토큰이 유효하지 않거나 만료되었습니다",
-			})
-			c.Abort()
+	tokenString := parts[1]
+	claims, err := ValidateToken(tokenString)
+	if err != nil {
+		return &authError{http.StatusUnauthorized, "토큰이 유효하지 않거나 만료되었습니다"}
+	}
+
+	active, err := TouchSession(c.Request.Context(), claims.UserID, claims.SessionID, c.ClientIP())
+	if err != nil || !active {
+		return &authError{http.StatusUnauthorized, "로그아웃되었거나 만료된 세션입니다"}
+	}
+
+	c.Set("user_id", claims.UserID)
+	c.Set("email", claims.Email)
+	c.Set("username", claims.Username)
+	c.Set("role", claims.Role)
+	c.Set("session_id", claims.SessionID)
+	c.Set("auth_method", AuthMethodSession)
+
+	return nil
+}
+
+// AuthMiddleware authenticates the request with a JWT access token.
+// When scopes are given, API keys holding all of those scopes are accepted as well;
+// without scopes the route is only available to logged in sessions.
+func AuthMiddleware(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := authenticate(c, scopes); err != nil {
+			err.abort(c)
 			return
 		}
+		c.Next()
+	}
+}
 
-		c.Set("user_id", claims.UserID)
-		c.Set("email", claims.Email)
-		c.Set("username", claims.Username)
-
+// OptionalAuthMiddleware is for public reads that show more to logged in users.
+// A valid session, or an API key holding the scopes, identifies the user; any other request,
+// including one with an expired token or a key without the scopes, is served as anonymous.
+func OptionalAuthMiddleware(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" || extractAPIKey(c) != "" {
+			// 인증에 실패해도 공개된 내용은 볼 수 있어야 하므로 막지 않습니다
+			authenticate(c, scopes)
+		}
 		c.Next()
 	}
 }
@@ -125,3 +185,17 @@ func GetUserIDFromContext(c *gin.Context) (uint, error) {
 
 	return id, nil
 }
+
+func GetSessionIDFromContext(c *gin.Context) (string, error) {
+	sessionID, exists := c.Get("session_id")
+	if !exists {
+		return "", fmt.Errorf("session_id not found in context")
+	}
+
+	id, ok := sessionID.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid session_id type")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"portfolio-server/internal/config"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// HMAC 서명에 허용하는 최소 비밀 키 길이 (바이트)
+const minJWTSecretLength = 32
+
+// placeholderJWTSecrets are example values that must never sign tokens in production
+var placeholderJWTSecrets = map[string]bool{
+	config.DefaultJWTSecret: true,
+	"your-super-secret-jwt-key-min-32-chars-change-this-in-production": true,
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is the document served at /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// verificationKey is a key that access tokens may be verified with
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    interface{}
+	jwk    *JWK
+}
+
+// keySet holds the key used to sign new tokens and every key that tokens are accepted from.
+// Asymmetric keys are identified by their RFC 7638 thumbprint, which is sent as the kid header.
+type keySet struct {
+	method     jwt.SigningMethod
+	signingKey interface{}
+	kid        string
+	verify     map[string]*verificationKey
+}
+
+func loadKeySet(cfg *config.JWTConfig, env string) (*keySet, error) {
+	ks := &keySet{verify: make(map[string]*verificationKey)}
+
+	switch cfg.Algorithm {
+	case "HS256":
+		if env == "production" && (placeholderJWTSecrets[cfg.Secret] || len(cfg.Secret) < minJWTSecretLength) {
+			return nil, fmt.Errorf("JWT_SECRET must be set to a random value of at least %d bytes in production", minJWTSecretLength)
+		}
+		ks.method = jwt.SigningMethodHS256
+		ks.signingKey = []byte(cfg.Secret)
+		// HMAC 키는 공개할 수 없으므로 JWKS에 포함하지 않고 kid 없이 서명합니다
+		ks.verify[""] = &verificationKey{method: jwt.SigningMethodHS256, key: []byte(cfg.Secret)}
+	case "RS256", "EdDSA":
+		if cfg.PrivateKeyFile == "" {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE is required for %s", cfg.Algorithm)
+		}
+		pemBytes, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT private key: %w", err)
+		}
+
+		var public crypto.PublicKey
+		if cfg.Algorithm == "RS256" {
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse RS256 private key: %w", err)
+			}
+			ks.method, ks.signingKey, public = jwt.SigningMethodRS256, private, &private.PublicKey
+		} else {
+			private, err := jwt.ParseEdPrivateKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse EdDSA private key: %w", err)
+			}
+			edPrivate, ok := private.(ed25519.PrivateKey)
+			if !ok {
+				return nil, fmt.Errorf("JWT private key is not an Ed25519 key")
+			}
+			ks.method, ks.signingKey, public = jwt.SigningMethodEdDSA, edPrivate, edPrivate.Public()
+		}
+
+		key, err := newVerificationKey(public)
+		if err != nil {
+			return nil, err
+		}
+		ks.kid = key.jwk.Kid
+		ks.verify[key.jwk.Kid] = key
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM %q (HS256, RS256 or EdDSA)", cfg.Algorithm)
+	}
+
+	for _, path := range cfg.VerificationKeyFiles {
+		key, err := loadVerificationKey(path)
+		if err != nil {
+			return nil, err
+		}
+		ks.verify[key.jwk.Kid] = key
+	}
+
+	return ks, nil
+}
+
+// loadVerificationKey reads an RSA or Ed25519 public key in PEM format
+func loadVerificationKey(path string) (*verificationKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT verification key %s: %w", path, err)
+	}
+
+	if public, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes); err == nil {
+		return newVerificationKey(public)
+	}
+	if public, err := jwt.ParseEdPublicKeyFromPEM(pemBytes); err == nil {
+		return newVerificationKey(public)
+	}
+	return nil, fmt.Errorf("JWT verification key %s is not an RSA or Ed25519 public key", path)
+}
+
+func newVerificationKey(public crypto.PublicKey) (*verificationKey, error) {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		n := encode(key.N.Bytes())
+		e := encode(big.NewInt(int64(key.E)).Bytes())
+		kid, err := jwkThumbprint(map[string]string{"e": e, "kty": "RSA", "n": n})
+		if err != nil {
+			return nil, err
+		}
+		return &verificationKey{
+			method: jwt.SigningMethodRS256,
+			key:    key,
+			jwk:    &JWK{Kty: "RSA", Kid: kid, Use: "sig", Alg: "RS256", N: n, E: e},
+		}, nil
+	case ed25519.PublicKey:
+		x := encode(key)
+		kid, err := jwkThumbprint(map[string]string{"crv": "Ed25519", "kty": "OKP", "x": x})
+		if err != nil {
+			return nil, err
+		}
+		return &verificationKey{
+			method: jwt.SigningMethodEdDSA,
+			key:    key,
+			jwk:    &JWK{Kty: "OKP", Kid: kid, Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: x},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWT public key type %T", public)
+	}
+}
+
+// jwkThumbprint computes the RFC 7638 thumbprint from the required members of a JWK.
+// encoding/json sorts map keys, which gives the lexicographic order the RFC requires.
+func jwkThumbprint(members map[string]string) (string, error) {
+	encoded, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// keyFunc selects the verification key by the kid header and checks that the algorithm matches it
+func (ks *keySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.verify[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.key, nil
+}
+
+// PublicJWKS returns the public keys that access tokens can be verified with
+func PublicJWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	if keys == nil {
+		return jwks
+	}
+	for _, key := range keys.verify {
+		if key.jwk != nil {
+			jwks.Keys = append(jwks.Keys, *key.jwk)
+		}
+	}
+	// 현재 서명 키를 먼저, 나머지는 kid 순서로 정렬합니다
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		if (jwks.Keys[i].Kid == keys.kid) != (jwks.Keys[j].Kid == keys.kid) {
+			return jwks.Keys[i].Kid == keys.kid
+		}
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+	return jwks
+}
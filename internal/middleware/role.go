@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole allows the request only if the authenticated user has one of the roles.
+// It must be placed after AuthMiddleware.
+func RequireRole(roles ...models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := GetRoleFromContext(c)
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "이 작업을 수행할 권한이 없습니다",
+		})
+		c.Abort()
+	}
+}
+
+func GetRoleFromContext(c *gin.Context) models.Role {
+	role, exists := c.Get("role")
+	if !exists {
+		return ""
+	}
+
+	r, ok := role.(models.Role)
+	if !ok {
+		return ""
+	}
+
+	return r
+}
+
+// GetActorFromContext returns the authenticated user for policy checks
+func GetActorFromContext(c *gin.Context) (policy.Actor, error) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		return policy.Actor{}, fmt.Errorf("actor not found in context: %w", err)
+	}
+
+	return policy.Actor{
+		UserID: userID,
+		Role:   GetRoleFromContext(c),
+	}, nil
+}
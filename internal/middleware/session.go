@@ -0,0 +1,356 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("refresh token is invalid or expired")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+)
+
+// 세션은 Redis에 저장됩니다.
+//   session:<id>            -> hash{user_id, created_at, last_seen, ua, ip, device_name, refresh_token}
+//   user_sessions:<user_id> -> 사용자의 세션 ID 집합
+//   refresh_token:<hash>    -> "<user_id>:<session_id>" (현재 유효한 Refresh Token)
+//   refresh_token_used:<hash> -> 이미 교체된 Refresh Token (재사용 탐지용)
+// 세션 키가 사라지면 해당 세션으로 발급된 Access/Refresh Token은 모두 거부됩니다.
+
+// last_seen은 요청마다 갱신하지 않고 이 간격이 지났을 때만 기록합니다
+const lastSeenUpdateInterval = time.Minute
+
+// ClientInfo describes the device a session was started from
+type ClientInfo struct {
+	UserAgent  string
+	IP         string
+	DeviceName string
+	// Referer 헤더. 글 유입 경로 통계에만 사용하며 세션에는 저장하지 않습니다
+	Referrer string
+}
+
+// ClientInfoFromContext reads the user agent and IP of the request. deviceName is an optional
+// label chosen by the user, e.g. "회사 노트북".
+func ClientInfoFromContext(c *gin.Context, deviceName string) ClientInfo {
+	return ClientInfo{
+		UserAgent:  c.Request.UserAgent(),
+		IP:         c.ClientIP(),
+		DeviceName: deviceName,
+		Referrer:   c.Request.Referer(),
+	}
+}
+
+// SessionInfo is a login session as shown to its owner
+type SessionInfo struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
+}
+
+func userSessionsKey(userID uint) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
+
+// RefreshTokenTTL returns how long a session (and its refresh token) stays alive without use
+func RefreshTokenTTL() time.Duration {
+	if jwtConfig == nil {
+		return 0
+	}
+	return time.Duration(jwtConfig.RefreshExpirationDays) * 24 * time.Hour
+}
+
+// CreateSession starts a new login session for the user and returns its ID
+func CreateSession(ctx context.Context, userID uint, client ClientInfo) (string, error) {
+	sessionID := uuid.New().String()
+	ttl := RefreshTokenTTL()
+	now := time.Now().Unix()
+
+	pipe := database.GetRedis().TxPipeline()
+	pipe.HSet(ctx, sessionKey(sessionID), map[string]interface{}{
+		"user_id":     userID,
+		"created_at":  now,
+		"last_seen":   now,
+		"ua":          client.UserAgent,
+		"ip":          client.IP,
+		"device_name": client.DeviceName,
+	})
+	pipe.Expire(ctx, sessionKey(sessionID), ttl)
+	pipe.SAdd(ctx, userSessionsKey(userID), sessionID)
+	pipe.Expire(ctx, userSessionsKey(userID), ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return sessionID, nil
+}
+
+// IsSessionActive reports whether the session exists and belongs to the user
+func IsSessionActive(ctx context.Context, userID uint, sessionID string) (bool, error) {
+	if sessionID == "" {
+		return false, nil
+	}
+
+	owner, err := database.GetRedis().HGet(ctx, sessionKey(sessionID), "user_id").Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return owner == strconv.FormatUint(uint64(userID), 10), nil
+}
+
+// TouchSession checks that the session is active like IsSessionActive and records the request
+// as the session's last activity. To keep authenticated requests to a single Redis read,
+// last_seen and ip are only written once per lastSeenUpdateInterval.
+func TouchSession(ctx context.Context, userID uint, sessionID, ip string) (bool, error) {
+	if sessionID == "" {
+		return false, nil
+	}
+
+	rdb := database.GetRedis()
+	values, err := rdb.HMGet(ctx, sessionKey(sessionID), "user_id", "last_seen").Result()
+	if err != nil {
+		return false, err
+	}
+
+	owner, _ := values[0].(string)
+	if owner == "" || owner != strconv.FormatUint(uint64(userID), 10) {
+		return false, nil
+	}
+
+	lastSeenValue, _ := values[1].(string)
+	lastSeen, _ := strconv.ParseInt(lastSeenValue, 10, 64)
+	now := time.Now()
+	if now.Sub(time.Unix(lastSeen, 0)) >= lastSeenUpdateInterval {
+		// 갱신 실패는 인증 결과에 영향을 주지 않습니다
+		rdb.HSet(ctx, sessionKey(sessionID), "last_seen", now.Unix(), "ip", ip)
+	}
+
+	return true, nil
+}
+
+// ListSessions returns the user's active sessions, most recently used first.
+// Sessions that expired on their own are removed from the user's session set.
+func ListSessions(ctx context.Context, userID uint) ([]SessionInfo, error) {
+	rdb := database.GetRedis()
+
+	sessionIDs, err := rdb.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	pipe := rdb.Pipeline()
+	commands := make([]*redis.MapStringStringCmd, len(sessionIDs))
+	for i, sessionID := range sessionIDs {
+		commands[i] = pipe.HGetAll(ctx, sessionKey(sessionID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	owner := strconv.FormatUint(uint64(userID), 10)
+	sessions := make([]SessionInfo, 0, len(sessionIDs))
+	for i, cmd := range commands {
+		fields := cmd.Val()
+		if fields["user_id"] != owner {
+			rdb.SRem(ctx, userSessionsKey(userID), sessionIDs[i])
+			continue
+		}
+
+		createdAt, _ := strconv.ParseInt(fields["created_at"], 10, 64)
+		lastSeen, _ := strconv.ParseInt(fields["last_seen"], 10, 64)
+		if lastSeen == 0 {
+			lastSeen = createdAt
+		}
+		sessions = append(sessions, SessionInfo{
+			ID:         sessionIDs[i],
+			DeviceName: fields["device_name"],
+			UserAgent:  fields["ua"],
+			IP:         fields["ip"],
+			CreatedAt:  time.Unix(createdAt, 0),
+			LastSeenAt: time.Unix(lastSeen, 0),
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession deletes a single session so its tokens can no longer be used
+func RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	rdb := database.GetRedis()
+
+	refreshHash, err := rdb.HGet(ctx, sessionKey(sessionID), "refresh_token").Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	pipe := rdb.TxPipeline()
+	pipe.Del(ctx, sessionKey(sessionID))
+	pipe.SRem(ctx, userSessionsKey(userID), sessionID)
+	if refreshHash != "" {
+		pipe.Del(ctx, refreshTokenKey(refreshHash))
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RevokeAllSessions deletes every session of the user ("logout everywhere")
+func RevokeAllSessions(ctx context.Context, userID uint) error {
+	sessionIDs, err := database.GetRedis().SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	for _, sessionID := range sessionIDs {
+		if err := RevokeSession(ctx, userID, sessionID); err != nil {
+			return err
+		}
+	}
+
+	return database.GetRedis().Del(ctx, userSessionsKey(userID)).Err()
+}
+
+// RevokeOtherSessions deletes every session of the user except keepSessionID
+func RevokeOtherSessions(ctx context.Context, userID uint, keepSessionID string) error {
+	sessionIDs, err := database.GetRedis().SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	for _, sessionID := range sessionIDs {
+		if sessionID == keepSessionID {
+			continue
+		}
+		if err := RevokeSession(ctx, userID, sessionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func refreshTokenKey(tokenHash string) string {
+	return fmt.Sprintf("refresh_token:%s", tokenHash)
+}
+
+func usedRefreshTokenKey(tokenHash string) string {
+	return fmt.Sprintf("refresh_token_used:%s", tokenHash)
+}
+
+// rotateRefreshToken deletes the refresh token (KEYS[1]) and marks it as used (KEYS[2], ARGV[1] seconds)
+// in one step, returning the session it belonged to. A concurrent request with the same token therefore
+// always finds the used marker and is treated as reuse.
+var rotateRefreshToken = redis.NewScript(`
+local value = redis.call("GET", KEYS[1])
+if not value then
+	return false
+end
+redis.call("DEL", KEYS[1])
+redis.call("SET", KEYS[2], value, "EX", ARGV[1])
+return value
+`)
+
+// IssueRefreshToken creates a new refresh token for the session and extends the session lifetime.
+// Only the SHA-256 hash of the token is stored.
+func IssueRefreshToken(ctx context.Context, userID uint, sessionID string) (string, error) {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	tokenHash := utils.HashToken(token)
+	ttl := RefreshTokenTTL()
+
+	pipe := database.GetRedis().TxPipeline()
+	pipe.Set(ctx, refreshTokenKey(tokenHash), fmt.Sprintf("%d:%s", userID, sessionID), ttl)
+	pipe.HSet(ctx, sessionKey(sessionID), "refresh_token", tokenHash, "last_seen", time.Now().Unix())
+	pipe.Expire(ctx, sessionKey(sessionID), ttl)
+	pipe.Expire(ctx, userSessionsKey(userID), ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ConsumeRefreshToken invalidates the refresh token and returns the session it belongs to.
+// Presenting an already rotated token revokes the whole session, since it means the token leaked.
+func ConsumeRefreshToken(ctx context.Context, token string) (uint, string, error) {
+	rdb := database.GetRedis()
+	tokenHash := utils.HashToken(token)
+
+	keys := []string{refreshTokenKey(tokenHash), usedRefreshTokenKey(tokenHash)}
+	value, err := rotateRefreshToken.Run(ctx, rdb, keys, int(RefreshTokenTTL().Seconds())).Text()
+	if err == redis.Nil {
+		usedBy, usedErr := rdb.Get(ctx, usedRefreshTokenKey(tokenHash)).Result()
+		if usedErr == redis.Nil {
+			return 0, "", ErrRefreshTokenInvalid
+		}
+		if usedErr != nil {
+			return 0, "", usedErr
+		}
+
+		userID, sessionID, parseErr := parseSessionRef(usedBy)
+		if parseErr != nil {
+			return 0, "", ErrRefreshTokenInvalid
+		}
+		if err := RevokeSession(ctx, userID, sessionID); err != nil {
+			return 0, "", err
+		}
+		return 0, "", ErrRefreshTokenReused
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	userID, sessionID, err := parseSessionRef(value)
+	if err != nil {
+		return 0, "", ErrRefreshTokenInvalid
+	}
+
+	active, err := IsSessionActive(ctx, userID, sessionID)
+	if err != nil {
+		return 0, "", err
+	}
+	if !active {
+		return 0, "", ErrRefreshTokenInvalid
+	}
+
+	return userID, sessionID, nil
+}
+
+func parseSessionRef(value string) (uint, string, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("malformed session reference")
+	}
+	userID, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, "", err
+	}
+	return uint(userID), parts[1], nil
+}
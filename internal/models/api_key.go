@@ -0,0 +1,66 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// API 키에 부여할 수 있는 권한 범위
+const (
+	// 글, 버전, 통계, 댓글 조회. articles:write를 가진 키는 이 권한도 가진 것으로 봅니다
+	ScopeArticlesRead  = "articles:read"
+	ScopeArticlesWrite = "articles:write"
+	ScopeCommentsWrite = "comments:write"
+	ScopeUploadsWrite  = "uploads:write"
+	ScopeProfileRead   = "profile:read"
+)
+
+// APIKeyScopes lists every scope an API key may be granted
+var APIKeyScopes = []string{ScopeArticlesRead, ScopeArticlesWrite, ScopeCommentsWrite, ScopeUploadsWrite, ScopeProfileRead}
+
+// APIKey is a personal access token for automation. Only the SHA-256 hash of the key is stored.
+// Revoking a key soft-deletes it.
+type APIKey struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	UserID     uint           `gorm:"not null;index" json:"-"`
+	Name       string         `gorm:"not null;type:varchar(100)" json:"name"`
+	Prefix     string         `gorm:"not null;type:varchar(16)" json:"prefix"`
+	KeyHash    string         `gorm:"uniqueIndex;not null;type:varchar(64)" json:"-"`
+	Scopes     string         `gorm:"not null;type:varchar(255)" json:"-"`
+	ExpiresAt  time.Time      `gorm:"not null" json:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList returns the scopes granted to the key
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(k.Scopes, " ")
+}
+
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse includes the plain key, which is only shown once
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
@@ -6,15 +6,56 @@ import (
 	"gorm.io/gorm"
 )
 
+// ArticleStatus determines who can see an article
+type ArticleStatus string
+
+const (
+	// ArticleStatusDraft is only visible to its author and staff
+	ArticleStatusDraft ArticleStatus = "draft"
+	// ArticleStatusPublished is visible to everyone
+	ArticleStatusPublished ArticleStatus = "published"
+	// ArticleStatusScheduled becomes published at PublishedAt
+	ArticleStatusScheduled ArticleStatus = "scheduled"
+	// ArticleStatusArchived is hidden from the public again but kept for its author
+	ArticleStatusArchived ArticleStatus = "archived"
+)
+
+// IsValid reports whether s is one of the known statuses
+func (s ArticleStatus) IsValid() bool {
+	switch s {
+	case ArticleStatusDraft, ArticleStatusPublished, ArticleStatusScheduled, ArticleStatusArchived:
+		return true
+	}
+	return false
+}
+
 type Article struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Title     string         `gorm:"not null;type:varchar(200)" json:"title"`
-	Content   string         `gorm:"not null;type:text" json:"content"`
-	AuthorID  uint           `gorm:"not null;index" json:"author_id"`
-	ViewCount int            `gorm:"default:0" json:"view_count"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	Title     string `gorm:"not null;type:varchar(200)" json:"title"`
+	Content   string `gorm:"not null;type:text" json:"content"`
+	AuthorID  uint   `gorm:"not null;index" json:"author_id"`
+	ViewCount int    `gorm:"default:0" json:"view_count"`
+	// 댓글을 작성하거나 삭제할 때 함께 갱신됩니다
+	CommentCount int `gorm:"not null;default:0" json:"comment_count"`
+	// 반응별 개수 ({"like": 3}). Reaction을 추가하거나 삭제할 때 같은 트랜잭션에서 갱신됩니다
+	ReactionCounts map[string]int `gorm:"type:jsonb;serializer:json" json:"reactions"`
+	// 제목에서 만든 URL용 이름. 제목이 바뀌면 이전 슬러그는 ArticleSlug에 남습니다
+	Slug string `gorm:"type:varchar(100);uniqueIndex" json:"slug"`
+	// 본문에서 마크다운을 걷어낸 앞부분. 목록에는 본문 대신 이 값을 보냅니다
+	Excerpt string `gorm:"type:varchar(300);not null;default:''" json:"excerpt"`
+	// 본문의 단어 수와 예상 읽기 시간(분). Excerpt와 함께 글을 저장할 때 계산합니다
+	WordCount   int `gorm:"not null;default:0" json:"word_count"`
+	ReadingTime int `gorm:"not null;default:0" json:"reading_time"`
+	// Content(마크다운)를 렌더링해 허용된 태그만 남긴 HTML과 제목 목차
+	ContentHTML string     `gorm:"type:text;not null;default:''" json:"content_html"`
+	TOC         []TOCEntry `gorm:"type:jsonb;serializer:json" json:"toc"`
+	// 상태 도입 이전에 작성된 글은 공개 상태로 마이그레이션됩니다
+	Status ArticleStatus `gorm:"type:varchar(20);not null;default:published;index" json:"status"`
+	// 공개된(또는 예약 공개될) 시각. 초안은 비어 있습니다
+	PublishedAt *time.Time     `gorm:"index" json:"published_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 
 	Author     User       `gorm:"foreignKey:AuthorID" json:"author"`
 	Categories []Category `gorm:"many2many:article_categories;" json:"-"`
@@ -24,31 +65,86 @@ func (Article) TableName() string {
 	return "articles"
 }
 
+// IsPublic reports whether everyone may read the article at the given time.
+// A scheduled article counts as public as soon as its time has come, even before the scheduler updates it.
+func (a *Article) IsPublic(now time.Time) bool {
+	switch a.Status {
+	case ArticleStatusPublished:
+		return true
+	case ArticleStatusScheduled:
+		return a.PublishedAt != nil && !a.PublishedAt.After(now)
+	}
+	return false
+}
+
+// TOCEntry is a heading of the rendered content. ID is the anchor of the heading in ContentHTML.
+type TOCEntry struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+	ID    string `json:"id"`
+}
+
 type CategoryInfo struct {
 	ID   uint   `json:"id"`
 	Name string `json:"name"`
 }
 
 type ArticleResponse struct {
-	ID         uint           `json:"id"`
-	Title      string         `json:"title"`
-	Content    string         `json:"content"`
-	AuthorID   uint           `json:"author_id"`
-	AuthorName string         `json:"author_name"`
-	ViewCount  int            `json:"view_count"`
-	Categories []CategoryInfo `json:"categories"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
+	ID           uint           `json:"id"`
+	Title        string         `json:"title"`
+	Slug         string         `json:"slug"`
+	Content      string         `json:"content"`
+	ContentHTML  string         `json:"content_html"`
+	TOC          []TOCEntry     `json:"toc"`
+	Excerpt      string         `json:"excerpt"`
+	WordCount    int            `json:"word_count"`
+	ReadingTime  int            `json:"reading_time"`
+	AuthorID     uint           `json:"author_id"`
+	AuthorName   string         `json:"author_name"`
+	ViewCount    int            `json:"view_count"`
+	CommentCount int            `json:"comment_count"`
+	Reactions    map[string]int `json:"reactions"`
+	// 로그인한 사용자가 남긴 반응
+	ViewerReactions []string `json:"viewer_reactions"`
+	// 로그인한 사용자가 읽기 목록에 담았는지
+	IsBookmarked bool           `json:"is_bookmarked"`
+	Status       ArticleStatus  `json:"status"`
+	PublishedAt  *time.Time     `json:"published_at"`
+	Categories   []CategoryInfo `json:"categories"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
 }
 
+// ArticleListResponse is a page of articles. NextCursor is opaque and only valid for the same sort order.
+// Each article is an ArticleResponse without content and viewer-specific fields, trimmed to the fields
+// asked for with fields=; is_bookmarked is only sent when asked for. Bookmark lists use the same shape
+// with bookmarked_at added to each article.
 type ArticleListResponse struct {
-	Articles   []ArticleResponse `json:"articles"`
-	NextCursor *uint             `json:"next_cursor"`
-	HasMore    bool              `json:"has_more"`
+	Articles   []map[string]interface{} `json:"articles"`
+	NextCursor *string                  `json:"next_cursor"`
+	HasMore    bool                     `json:"has_more"`
+}
+
+// RankedArticleInfo is an article in the trending list or a leaderboard.
+// Score is the trending score, or the views in the period for a leaderboard.
+type RankedArticleInfo struct {
+	Rank  int     `json:"rank"`
+	ID    uint    `json:"id"`
+	Title string  `json:"title"`
+	Slug  string  `json:"slug"`
+	Score float64 `json:"score"`
+}
+
+// RankingResponse is a cached ranking. UpdatedAt is when it was last computed.
+type RankingResponse struct {
+	Period    string              `json:"period"`
+	Articles  []RankedArticleInfo `json:"articles"`
+	UpdatedAt *time.Time          `json:"updated_at"`
 }
 
 type TopArticleInfo struct {
 	ID        uint   `json:"id"`
 	Title     string `json:"title"`
+	Slug      string `json:"slug"`
 	ViewCount int    `json:"view_count"`
 }
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// ArticleRevision is an immutable snapshot of an article's title and content.
+// A revision is stored every time the title or content changes; Revision counts up from 1 per article.
+type ArticleRevision struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	ArticleID uint   `gorm:"not null;uniqueIndex:idx_article_revision" json:"article_id"`
+	Revision  int    `gorm:"not null;uniqueIndex:idx_article_revision" json:"revision"`
+	Title     string `gorm:"not null;type:varchar(200)" json:"title"`
+	Content   string `gorm:"not null;type:text" json:"content"`
+	// 이 버전을 만든 사용자 (작성자 또는 editor, admin)
+	EditorID uint `gorm:"not null;index" json:"editor_id"`
+	// 복원으로 만들어진 버전이면 복원한 원래 버전 번호
+	RestoredFrom *int      `json:"restored_from"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	Editor User `gorm:"foreignKey:EditorID" json:"-"`
+}
+
+func (ArticleRevision) TableName() string {
+	return "article_revisions"
+}
+
+type ArticleRevisionResponse struct {
+	Revision     int       `json:"revision"`
+	Title        string    `json:"title"`
+	Content      string    `json:"content,omitempty"`
+	EditorID     uint      `json:"editor_id"`
+	EditorName   string    `json:"editor_name"`
+	RestoredFrom *int      `json:"restored_from"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ArticleRevisionListResponse struct {
+	Revisions  []ArticleRevisionResponse `json:"revisions"`
+	NextCursor *int                      `json:"next_cursor"`
+	HasMore    bool                      `json:"has_more"`
+}
+
+// DiffLine is one line of a line-based diff. Op is "equal", "insert" or "delete";
+// OldLine and NewLine are 1-based line numbers in each version (0 when the line is absent there).
+type DiffLine struct {
+	Op      string `json:"op"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+	Text    string `json:"text"`
+}
+
+type ArticleRevisionDiffResponse struct {
+	ArticleID uint       `json:"article_id"`
+	From      int        `json:"from"`
+	To        int        `json:"to"`
+	OldTitle  string     `json:"old_title"`
+	NewTitle  string     `json:"new_title"`
+	Added     int        `json:"added"`
+	Removed   int        `json:"removed"`
+	Lines     []DiffLine `json:"lines"`
+}
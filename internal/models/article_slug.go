@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ArticleSlug is a slug an article used before its title changed.
+// Requests for an old slug are redirected to the article's current slug.
+type ArticleSlug struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ArticleID uint      `gorm:"not null;index" json:"article_id"`
+	Slug      string    `gorm:"not null;type:varchar(100);uniqueIndex" json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ArticleSlug) TableName() string {
+	return "article_slugs"
+}
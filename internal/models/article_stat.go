@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// ArticleDailyStat is the rollup of an article's views on one day.
+// Views counts de-duplicated views like Article.ViewCount; UniqueVisitors counts distinct viewers of the day.
+type ArticleDailyStat struct {
+	ArticleID      uint      `gorm:"primaryKey;autoIncrement:false" json:"article_id"`
+	Date           time.Time `gorm:"primaryKey;type:date" json:"date"`
+	Views          int       `gorm:"not null;default:0" json:"views"`
+	UniqueVisitors int       `gorm:"not null;default:0" json:"unique_visitors"`
+	// 유입 경로(Referer의 호스트)별 조회수. 직접 방문은 "direct"
+	Referrers map[string]int `gorm:"type:jsonb;serializer:json" json:"referrers"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func (ArticleDailyStat) TableName() string {
+	return "article_daily_stats"
+}
+
+// DailyStat is one day of a stats time series. Date is formatted as YYYY-MM-DD.
+type DailyStat struct {
+	Date           string `json:"date"`
+	Views          int    `json:"views"`
+	UniqueVisitors int    `json:"unique_visitors"`
+}
+
+type ReferrerStat struct {
+	Referrer string `json:"referrer"`
+	Views    int    `json:"views"`
+}
+
+// ArticleStatsResponse is the time series of an article between From and To, both inclusive.
+// Every day of the range is present, days without views have zeros.
+// VisitorDays is the sum of the daily unique visitors: a reader who came back on three days counts three times.
+// Visitors are only kept per day, so a unique count over the range is not available.
+type ArticleStatsResponse struct {
+	ArticleID   uint           `json:"article_id"`
+	From        string         `json:"from"`
+	To          string         `json:"to"`
+	Views       int            `json:"views"`
+	VisitorDays int            `json:"visitor_days"`
+	Days        []DailyStat    `json:"days"`
+	Referrers   []ReferrerStat `json:"referrers"`
+}
+
+type AuthorArticleStat struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+	// 기간 중 조회수
+	Views        int `json:"views"`
+	ViewCount    int `json:"view_count"`
+	CommentCount int `json:"comment_count"`
+}
+
+// AuthorStatsResponse summarizes all articles of an author. The totals are all-time;
+// Views, Days and TopArticles cover From to To.
+type AuthorStatsResponse struct {
+	From          string              `json:"from"`
+	To            string              `json:"to"`
+	ArticleCount  int64               `json:"article_count"`
+	TotalViews    int64               `json:"total_views"`
+	TotalComments int64               `json:"total_comments"`
+	Views         int                 `json:"views"`
+	Days          []DailyStat         `json:"days"`
+	TopArticles   []AuthorArticleStat `json:"top_articles"`
+}
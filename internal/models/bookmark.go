@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Bookmark is an article on a user's reading list
+type Bookmark struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_bookmarks_user_article,priority:1" json:"user_id"`
+	ArticleID uint      `gorm:"not null;uniqueIndex:idx_bookmarks_user_article,priority:2;index" json:"article_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (Bookmark) TableName() string {
+	return "bookmarks"
+}
+
+type BookmarkResponse struct {
+	ArticleID    uint      `json:"article_id"`
+	BookmarkedAt time.Time `json:"bookmarked_at"`
+}
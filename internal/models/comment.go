@@ -3,24 +3,29 @@ package models
 import "time"
 
 type Comment struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Content   string    `json:"content" gorm:"type:text;not null"`
-	AuthorID  uint      `json:"author_id" gorm:"not null"`
-	Author    User      `json:"author" gorm:"foreignKey:AuthorID"`
-	ArticleID uint      `json:"article_id" gorm:"not null"`
-	Article   Article   `json:"-" gorm:"foreignKey:ArticleID"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint    `json:"id" gorm:"primaryKey"`
+	Content   string  `json:"content" gorm:"type:text;not null"`
+	AuthorID  uint    `json:"author_id" gorm:"not null"`
+	Author    User    `json:"author" gorm:"foreignKey:AuthorID"`
+	ArticleID uint    `json:"article_id" gorm:"not null"`
+	Article   Article `json:"-" gorm:"foreignKey:ArticleID"`
+	// 반응별 개수 ({"like": 3})
+	ReactionCounts map[string]int `json:"reactions" gorm:"type:jsonb;serializer:json"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
 }
 
 type CommentResponse struct {
-	ID         uint      `json:"id"`
-	Content    string    `json:"content"`
-	AuthorID   uint      `json:"author_id"`
-	AuthorName string    `json:"author_name"`
-	ArticleID  uint      `json:"article_id"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uint   `json:"id"`
+	Content    string `json:"content"`
+	AuthorID   uint   `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	ArticleID  uint   `json:"article_id"`
+	// 반응별 개수와 로그인한 사용자가 남긴 반응
+	Reactions       map[string]int `json:"reactions"`
+	ViewerReactions []string       `json:"viewer_reactions"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
 }
 
 type CommentListResponse struct {
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// 로그인 시도 결과
+const (
+	LoginResultSuccess            = "success"
+	LoginResultInvalidCredentials = "invalid_credentials"
+	LoginResultLocked             = "locked"
+	LoginResultRateLimited        = "rate_limited"
+	// 비밀번호는 맞았지만 TOTP 코드나 복구 코드가 틀린 경우
+	LoginResultInvalidTwoFactor = "invalid_two_factor"
+	// 없거나 만료되었거나 이미 사용한 로그인 링크
+	LoginResultInvalidMagicLink = "invalid_magic_link"
+	// state가 맞지 않거나, 제공자 인증이 실패했거나, 계정에 연결할 수 없는 소셜 로그인
+	LoginResultOAuthFailed = "oauth_failed"
+)
+
+// LoginAttempt records a login attempt (password, magic link or social login) for review by admins.
+// UserID is empty when no account exists for the email; Email is empty when the attempt did not name one.
+type LoginAttempt struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    *uint     `gorm:"index" json:"user_id"`
+	Email     string    `gorm:"not null;type:varchar(255);index" json:"email"`
+	IP        string    `gorm:"not null;type:varchar(45);index" json:"ip"`
+	UserAgent string    `gorm:"type:varchar(512)" json:"user_agent"`
+	Success   bool      `gorm:"not null" json:"success"`
+	Result    string    `gorm:"not null;type:varchar(30)" json:"result"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+type LoginAttemptListResponse struct {
+	Attempts   []LoginAttempt `json:"attempts"`
+	NextCursor *uint          `json:"next_cursor"`
+	HasMore    bool           `json:"has_more"`
+}
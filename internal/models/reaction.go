@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// ReactionType is one of the fixed reactions readers can leave on articles and comments
+type ReactionType string
+
+const (
+	ReactionLike      ReactionType = "like"      // 👍
+	ReactionLove      ReactionType = "love"      // ❤️
+	ReactionLaugh     ReactionType = "laugh"     // 😂
+	ReactionWow       ReactionType = "wow"       // 😮
+	ReactionSad       ReactionType = "sad"       // 😢
+	ReactionCelebrate ReactionType = "celebrate" // 🎉
+)
+
+// IsValid reports whether t is one of the known reactions
+func (t ReactionType) IsValid() bool {
+	switch t {
+	case ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionSad, ReactionCelebrate:
+		return true
+	}
+	return false
+}
+
+// 반응을 남길 수 있는 대상
+const (
+	ReactionTargetArticle = "article"
+	ReactionTargetComment = "comment"
+)
+
+// Reaction is a user's reaction to an article or comment. A user can leave each reaction once per target;
+// the counts are kept in the target's ReactionCounts.
+type Reaction struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	UserID     uint         `gorm:"not null;uniqueIndex:idx_reactions_user_target,priority:1" json:"user_id"`
+	TargetType string       `gorm:"type:varchar(20);not null;uniqueIndex:idx_reactions_user_target,priority:2;index:idx_reactions_target,priority:1" json:"target_type"`
+	TargetID   uint         `gorm:"not null;uniqueIndex:idx_reactions_user_target,priority:3;index:idx_reactions_target,priority:2" json:"target_id"`
+	Type       ReactionType `gorm:"type:varchar(20);not null;uniqueIndex:idx_reactions_user_target,priority:4" json:"type"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+func (Reaction) TableName() string {
+	return "reactions"
+}
+
+// ReactionSummary is the state of a target's reactions after a reaction was added or removed
+type ReactionSummary struct {
+	Reactions       map[string]int `json:"reactions"`
+	ViewerReactions []string       `json:"viewer_reactions"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// 검색 결과 종류
+const (
+	SearchResultArticle = "article"
+	SearchResultComment = "comment"
+)
+
+// SearchResult is an article or a comment matching a search.
+// For comments, Title and Slug are those of the article the comment belongs to.
+type SearchResult struct {
+	Type       string    `json:"type"`
+	ArticleID  uint      `json:"article_id"`
+	CommentID  *uint     `json:"comment_id,omitempty"`
+	Title      string    `json:"title"`
+	Slug       string    `json:"slug"`
+	AuthorID   uint      `json:"author_id"`
+	AuthorName string    `json:"author_name"`
+	Snippet    string    `json:"snippet"`
+	Rank       float64   `json:"rank"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+	HasMore bool           `json:"has_more"`
+}
@@ -6,14 +6,43 @@ import (
 	"gorm.io/gorm"
 )
 
+// Role determines what a user is allowed to do
+type Role string
+
+const (
+	// RoleAdmin can do everything, including managing users and overriding ownership
+	RoleAdmin Role = "admin"
+	// RoleEditor can edit any article, moderate comments and manage categories
+	RoleEditor Role = "editor"
+	// RoleAuthor can write articles and edit their own content
+	RoleAuthor Role = "author"
+	// RoleReader can only comment
+	RoleReader Role = "reader"
+)
+
+// IsValid reports whether r is one of the known roles
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleEditor, RoleAuthor, RoleReader:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Email     string         `gorm:"uniqueIndex;not null" json:"email"`
-	Username  string         `gorm:"uniqueIndex;not null" json:"username"`
-	Password  string         `gorm:"not null" json:"-"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Email    string `gorm:"uniqueIndex;not null" json:"email"`
+	Username string `gorm:"uniqueIndex;not null" json:"username"`
+	Password string `gorm:"not null" json:"-"`
+	Role     Role   `gorm:"type:varchar(20);not null;default:author" json:"role"`
+	// TOTP 2단계 인증. TOTPSecret은 TwoFactorEnabled일 때만 설정됩니다
+	TOTPSecret       string `gorm:"type:varchar(64)" json:"-"`
+	TwoFactorEnabled bool   `gorm:"not null;default:false" json:"two_factor_enabled"`
+	// 이메일 인증을 마친 시각. 인증 절차 도입 이전에 가입한 계정은 비어 있습니다
+	EmailVerifiedAt *time.Time     `json:"email_verified_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 
 	Articles []Article `gorm:"foreignKey:AuthorID" json:"-"`
 }
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// UserIdentity links an account at an external identity provider (GitHub, Google, ...) to a user.
+// Subject is the provider's stable user ID; the email is kept for display only.
+type UserIdentity struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"-"`
+	Provider  string    `gorm:"not null;type:varchar(50);uniqueIndex:idx_user_identities_provider_subject" json:"provider"`
+	Subject   string    `gorm:"not null;type:varchar(255);uniqueIndex:idx_user_identities_provider_subject" json:"-"`
+	Email     string    `gorm:"type:varchar(255)" json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
@@ -0,0 +1,62 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type gitHubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+type gitHubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// fetchGitHubIdentity reads the user from the GitHub API. GitHub does not issue ID tokens, and the
+// email on the profile may be hidden or unverified, so the primary email comes from /user/emails.
+func (p *Provider) fetchGitHubIdentity(ctx context.Context, accessToken string) (*Identity, error) {
+	var user gitHubUser
+	if err := p.getGitHub(ctx, p.cfg.UserInfoURL, accessToken, &user); err != nil {
+		return nil, fmt.Errorf("GitHub user request failed: %w", err)
+	}
+	if user.ID == 0 {
+		return nil, fmt.Errorf("GitHub user response has no id")
+	}
+
+	var emails []gitHubEmail
+	if err := p.getGitHub(ctx, strings.TrimRight(p.cfg.UserInfoURL, "/")+"/emails", accessToken, &emails); err != nil {
+		return nil, fmt.Errorf("GitHub emails request failed: %w", err)
+	}
+
+	identity := &Identity{
+		Subject:           strconv.FormatInt(user.ID, 10),
+		Name:              user.Name,
+		PreferredUsername: user.Login,
+	}
+	for _, email := range emails {
+		if email.Primary {
+			identity.Email = email.Email
+			identity.EmailVerified = email.Verified
+			break
+		}
+	}
+	return identity, nil
+}
+
+func (p *Provider) getGitHub(ctx context.Context, url, accessToken string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	return p.doJSON(req, v)
+}
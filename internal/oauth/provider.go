@@ -0,0 +1,256 @@
+// Package oauth implements the client side of the OAuth 2.0 authorization code flow with PKCE
+// and OpenID Connect ID token verification, for social login.
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"portfolio-server/internal/config"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Identity is what the provider tells us about the user who signed in
+type Identity struct {
+	// Subject is the provider's stable user ID
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// PreferredUsername is used as the base of the username for new accounts
+	PreferredUsername string
+}
+
+// Provider is an OAuth 2.0 / OpenID Connect client for one identity provider
+type Provider struct {
+	cfg    config.OAuthProviderConfig
+	client *http.Client
+
+	mu         sync.Mutex
+	discovered bool
+	keys       *keyCache
+}
+
+// NewProviders creates a client for every configured provider, keyed by name
+func NewProviders(cfg config.OAuthConfig) map[string]*Provider {
+	providers := make(map[string]*Provider, len(cfg.Providers))
+	for _, providerCfg := range cfg.Providers {
+		providers[providerCfg.Name] = &Provider{
+			cfg:    providerCfg,
+			client: &http.Client{Timeout: 10 * time.Second},
+			keys:   &keyCache{},
+		}
+	}
+	return providers
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// isOIDC reports whether the provider issues ID tokens
+func (p *Provider) isOIDC() bool {
+	return p.cfg.Issuer != ""
+}
+
+// CodeChallenge derives the S256 PKCE challenge from a code verifier
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL returns the URL to send the user to. The nonce is only used by OpenID Connect providers.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, codeVerifier, nonce string) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {CodeChallenge(codeVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+	if p.isOIDC() {
+		query.Set("nonce", nonce)
+	}
+
+	separator := "?"
+	if strings.Contains(p.cfg.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.cfg.AuthURL + separator + query.Encode(), nil
+}
+
+// tokenResponse is the token endpoint response (RFC 6749 5.1, 5.2)
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange redeems the authorization code and returns the identity of the user who signed in
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token tokenResponse
+	if err := p.doJSON(req, &token); err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	// GitHub은 실패해도 200과 함께 error 필드를 반환합니다
+	if token.Error != "" {
+		return nil, fmt.Errorf("token request failed: %s %s", token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access_token")
+	}
+
+	if p.isOIDC() {
+		if token.IDToken == "" {
+			return nil, fmt.Errorf("token response has no id_token")
+		}
+		return p.verifyIDToken(ctx, token.IDToken, nonce)
+	}
+	if p.cfg.Name == "github" {
+		return p.fetchGitHubIdentity(ctx, token.AccessToken)
+	}
+	return p.fetchUserInfo(ctx, token.AccessToken)
+}
+
+// discoveryDocument holds the fields we use from an OpenID Provider configuration
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// discover fills in endpoints that are not configured from the issuer's discovery document
+func (p *Provider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovered || !p.isOIDC() {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(p.cfg.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return err
+	}
+
+	var doc discoveryDocument
+	if err := p.doJSON(req, &doc); err != nil {
+		return fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	if doc.Issuer != p.cfg.Issuer {
+		return fmt.Errorf("OIDC discovery returned issuer %q, expected %q", doc.Issuer, p.cfg.Issuer)
+	}
+
+	if p.cfg.AuthURL == "" {
+		p.cfg.AuthURL = doc.AuthorizationEndpoint
+	}
+	if p.cfg.TokenURL == "" {
+		p.cfg.TokenURL = doc.TokenEndpoint
+	}
+	if p.cfg.UserInfoURL == "" {
+		p.cfg.UserInfoURL = doc.UserInfoEndpoint
+	}
+	if p.cfg.JWKSURL == "" {
+		p.cfg.JWKSURL = doc.JWKSURI
+	}
+	p.discovered = true
+
+	return nil
+}
+
+// fetchUserInfo reads the standard OpenID Connect claims from the userinfo endpoint
+func (p *Provider) fetchUserInfo(ctx context.Context, accessToken string) (*Identity, error) {
+	if p.cfg.UserInfoURL == "" {
+		return nil, fmt.Errorf("provider %s has no userinfo endpoint", p.cfg.Name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var claims map[string]interface{}
+	if err := p.doJSON(req, &claims); err != nil {
+		return nil, fmt.Errorf("userinfo request failed: %w", err)
+	}
+
+	identity := identityFromClaims(claims)
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("userinfo response has no sub")
+	}
+	return identity, nil
+}
+
+// identityFromClaims maps standard OpenID Connect claims to an Identity
+func identityFromClaims(claims map[string]interface{}) *Identity {
+	identity := &Identity{}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	identity.PreferredUsername, _ = claims["preferred_username"].(string)
+
+	// 일부 제공자는 email_verified를 문자열로 보냅니다
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+	return identity
+}
+
+// doJSON sends req and decodes a successful JSON response into v
+func (p *Provider) doJSON(req *http.Request, v interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
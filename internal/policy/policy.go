@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"portfolio-server/internal/models"
+	"time"
+)
+
+// Actor is the user performing an action
+type Actor struct {
+	UserID uint
+	Role   models.Role
+}
+
+func (a Actor) IsAdmin() bool {
+	return a.Role == models.RoleAdmin
+}
+
+// isStaff reports whether the actor may act on content owned by others
+func (a Actor) isStaff() bool {
+	return a.Role == models.RoleAdmin || a.Role == models.RoleEditor
+}
+
+func (a Actor) owns(ownerID uint) bool {
+	return a.UserID != 0 && a.UserID == ownerID
+}
+
+func CanCreateArticle(a Actor) bool {
+	return a.isStaff() || a.Role == models.RoleAuthor
+}
+
+// CanViewArticle reports whether the actor may read the article. Unpublished articles are
+// only visible to their author and staff.
+func CanViewArticle(a Actor, article *models.Article, now time.Time) bool {
+	return article.IsPublic(now) || a.isStaff() || a.owns(article.AuthorID)
+}
+
+// CanViewUnpublishedArticles reports whether the actor may list other authors' unpublished articles
+func CanViewUnpublishedArticles(a Actor) bool {
+	return a.isStaff()
+}
+
+// CanViewArticleStats reports whether the actor may see the article's analytics
+func CanViewArticleStats(a Actor, article *models.Article) bool {
+	return a.isStaff() || a.owns(article.AuthorID)
+}
+
+func CanEditArticle(a Actor, article *models.Article) bool {
+	return a.isStaff() || (a.Role == models.RoleAuthor && a.owns(article.AuthorID))
+}
+
+func CanDeleteArticle(a Actor, article *models.Article) bool {
+	return a.isStaff() || a.owns(article.AuthorID)
+}
+
+func CanEditComment(a Actor, comment *models.Comment) bool {
+	return a.IsAdmin() || a.owns(comment.AuthorID)
+}
+
+// CanModerateComment reports whether the actor may delete the comment
+func CanModerateComment(a Actor, comment *models.Comment) bool {
+	return a.isStaff() || a.owns(comment.AuthorID)
+}
+
+func CanManageCategories(a Actor) bool {
+	return a.isStaff()
+}
+
+// CanDeleteUpload reports whether the actor may delete an uploaded file.
+// Files uploaded before owners were recorded have ownerID 0 and can only be deleted by admins.
+func CanDeleteUpload(a Actor, ownerID uint) bool {
+	return a.IsAdmin() || (ownerID != 0 && a.owns(ownerID))
+}
+
+func CanManageUsers(a Actor) bool {
+	return a.IsAdmin()
+}
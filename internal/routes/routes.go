@@ -3,6 +3,7 @@ package routes
 import (
 	"portfolio-server/internal/handlers"
 	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +16,8 @@ func SetupRoutes(router *gin.Engine) {
 		})
 	})
 
+	router.GET("/.well-known/jwks.json", handlers.GetJWKS)
+
 	authHandler := handlers.NewAuthHandler()
 	auth := router.Group("/auth")
 	{
@@ -22,43 +25,120 @@ func SetupRoutes(router *gin.Engine) {
 		auth.POST("/verify-code", authHandler.VerifyCode)
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/login", authHandler.Login)
-		auth.GET("/profile", middleware.AuthMiddleware(), authHandler.GetProfile)
+		auth.POST("/magic-link", authHandler.SendMagicLink)
+		auth.POST("/magic-link/verify", authHandler.VerifyMagicLink)
+		auth.GET("/oauth/providers", authHandler.GetOAuthProviders)
+		auth.GET("/oauth/:provider/authorize", authHandler.OAuthAuthorize)
+		auth.POST("/oauth/:provider/callback", authHandler.OAuthCallback)
+		auth.POST("/refresh", authHandler.Refresh)
+		auth.POST("/logout", middleware.AuthMiddleware(), authHandler.Logout)
+		auth.POST("/logout-all", middleware.AuthMiddleware(), authHandler.LogoutAll)
+		auth.GET("/sessions", middleware.AuthMiddleware(), authHandler.GetSessions)
+		auth.DELETE("/sessions/:id", middleware.AuthMiddleware(), authHandler.RevokeSession)
+		auth.POST("/password/forgot", authHandler.ForgotPassword)
+		auth.POST("/password/reset", authHandler.ResetPassword)
+		auth.PUT("/password", middleware.AuthMiddleware(), authHandler.ChangePassword)
+		auth.POST("/email", middleware.AuthMiddleware(), authHandler.RequestEmailChange)
+		auth.POST("/email/confirm", middleware.AuthMiddleware(), authHandler.ConfirmEmailChange)
+		auth.POST("/reauth", middleware.AuthMiddleware(), authHandler.RequestReauthCode)
+		auth.DELETE("/account", middleware.AuthMiddleware(), authHandler.DeleteAccount)
+		auth.GET("/profile", middleware.AuthMiddleware(models.ScopeProfileRead), authHandler.GetProfile)
+	}
+
+	twoFactorHandler := handlers.NewTwoFactorHandler()
+	router.POST("/auth/login/2fa", twoFactorHandler.CompleteLogin)
+	twoFactor := router.Group("/auth/2fa", middleware.AuthMiddleware())
+	{
+		twoFactor.POST("/setup", twoFactorHandler.Setup)
+		twoFactor.POST("/confirm", twoFactorHandler.Confirm)
+		twoFactor.POST("/disable", twoFactorHandler.Disable)
+	}
+
+	apiKeyHandler := handlers.NewAPIKeyHandler()
+	apiKeys := router.Group("/auth/api-keys", middleware.AuthMiddleware())
+	{
+		apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+		apiKeys.GET("", apiKeyHandler.GetAPIKeys)
+		apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
 	}
 
 	articleHandler := handlers.NewArticleHandler()
 	commentHandler := handlers.NewCommentHandler()
+	revisionHandler := handlers.NewArticleRevisionHandler()
+	rankingHandler := handlers.NewRankingHandler()
+	statsHandler := handlers.NewStatsHandler()
+	reactionHandler := handlers.NewReactionHandler()
+	bookmarkHandler := handlers.NewBookmarkHandler()
 	articles := router.Group("/articles")
 	{
 		// Static routes must come before dynamic routes
 		articles.GET("/top/views", articleHandler.GetTopArticles)
+		articles.GET("/trending", rankingHandler.GetTrending)
+		articles.GET("/leaderboard", rankingHandler.GetLeaderboard)
+		articles.GET("/by-slug/:slug", middleware.OptionalAuthMiddleware(models.ScopeArticlesRead), articleHandler.GetArticleBySlug)
 		
-		articles.GET("", articleHandler.GetArticles)
-		articles.GET("/:id", articleHandler.GetArticle)
-		articles.POST("", middleware.AuthMiddleware(), articleHandler.CreateArticle)
-		articles.PUT("/:id", middleware.AuthMiddleware(), articleHandler.UpdateArticle)
-		articles.DELETE("/:id", middleware.AuthMiddleware(), articleHandler.DeleteArticle)
+		articles.GET("", middleware.OptionalAuthMiddleware(models.ScopeArticlesRead), articleHandler.GetArticles)
+		articles.GET("/:id", middleware.OptionalAuthMiddleware(models.ScopeArticlesRead), articleHandler.GetArticle)
+		articles.POST("", middleware.AuthMiddleware(models.ScopeArticlesWrite), articleHandler.CreateArticle)
+		articles.PUT("/:id", middleware.AuthMiddleware(models.ScopeArticlesWrite), articleHandler.UpdateArticle)
+		articles.DELETE("/:id", middleware.AuthMiddleware(models.ScopeArticlesWrite), articleHandler.DeleteArticle)
 		
+		// Revision routes
+		articles.GET("/:id/revisions", middleware.AuthMiddleware(models.ScopeArticlesRead), revisionHandler.GetRevisions)
+		articles.GET("/:id/revisions/diff", middleware.AuthMiddleware(models.ScopeArticlesRead), revisionHandler.DiffRevisions)
+		articles.GET("/:id/revisions/:rev", middleware.AuthMiddleware(models.ScopeArticlesRead), revisionHandler.GetRevision)
+		articles.POST("/:id/revisions/:rev/restore", middleware.AuthMiddleware(models.ScopeArticlesWrite), revisionHandler.RestoreRevision)
+
+		// Stats routes
+		articles.GET("/:id/stats", middleware.AuthMiddleware(models.ScopeArticlesRead), statsHandler.GetArticleStats)
+
 		// Comments routes
-		articles.GET("/:id/comments", commentHandler.GetComments)
-		articles.POST("/:id/comments", middleware.AuthMiddleware(), commentHandler.CreateComment)
-		articles.PUT("/:id/comments/:commentId", middleware.AuthMiddleware(), commentHandler.UpdateComment)
-		articles.DELETE("/:id/comments/:commentId", middleware.AuthMiddleware(), commentHandler.DeleteComment)
+		articles.GET("/:id/comments", middleware.OptionalAuthMiddleware(models.ScopeArticlesRead), commentHandler.GetComments)
+		articles.POST("/:id/comments", middleware.AuthMiddleware(models.ScopeCommentsWrite), commentHandler.CreateComment)
+		articles.PUT("/:id/comments/:commentId", middleware.AuthMiddleware(models.ScopeCommentsWrite), commentHandler.UpdateComment)
+		articles.DELETE("/:id/comments/:commentId", middleware.AuthMiddleware(models.ScopeCommentsWrite), commentHandler.DeleteComment)
+
+		// Reaction routes
+		articles.PUT("/:id/reactions/:type", middleware.AuthMiddleware(), reactionHandler.AddArticleReaction)
+		articles.DELETE("/:id/reactions/:type", middleware.AuthMiddleware(), reactionHandler.RemoveArticleReaction)
+		articles.PUT("/:id/comments/:commentId/reactions/:type", middleware.AuthMiddleware(), reactionHandler.AddCommentReaction)
+		articles.DELETE("/:id/comments/:commentId/reactions/:type", middleware.AuthMiddleware(), reactionHandler.RemoveCommentReaction)
+
+		// Bookmark routes
+		articles.POST("/:id/bookmark", middleware.AuthMiddleware(), bookmarkHandler.AddBookmark)
+		articles.DELETE("/:id/bookmark", middleware.AuthMiddleware(), bookmarkHandler.RemoveBookmark)
+	}
+
+	me := router.Group("/me", middleware.AuthMiddleware())
+	{
+		me.GET("/stats", statsHandler.GetMyStats)
+		me.GET("/bookmarks", bookmarkHandler.GetMyBookmarks)
 	}
 
+	searchHandler := handlers.NewSearchHandler()
+	router.GET("/search", searchHandler.Search)
+
 	categoryHandler := handlers.NewCategoryHandler()
 	categories := router.Group("/categories")
 	{
 		categories.GET("", categoryHandler.GetCategories)
 		categories.GET("/:id", categoryHandler.GetCategory)
-		categories.POST("", middleware.AuthMiddleware(), categoryHandler.CreateCategory)
-		categories.PUT("/:id", middleware.AuthMiddleware(), categoryHandler.UpdateCategory)
-		categories.DELETE("/:id", middleware.AuthMiddleware(), categoryHandler.DeleteCategory)
+		categories.POST("", middleware.AuthMiddleware(), middleware.RequireRole(models.RoleAdmin, models.RoleEditor), categoryHandler.CreateCategory)
+		categories.PUT("/:id", middleware.AuthMiddleware(), middleware.RequireRole(models.RoleAdmin, models.RoleEditor), categoryHandler.UpdateCategory)
+		categories.DELETE("/:id", middleware.AuthMiddleware(), middleware.RequireRole(models.RoleAdmin, models.RoleEditor), categoryHandler.DeleteCategory)
 	}
 
 	uploadHandler := handlers.NewUploadHandler()
 	upload := router.Group("/upload")
 	{
-		upload.POST("/image", middleware.AuthMiddleware(), uploadHandler.UploadImage)
-		upload.DELETE("/image", middleware.AuthMiddleware(), uploadHandler.DeleteImage)
+		upload.POST("/image", middleware.AuthMiddleware(models.ScopeUploadsWrite), uploadHandler.UploadImage)
+		upload.DELETE("/image", middleware.AuthMiddleware(models.ScopeUploadsWrite), uploadHandler.DeleteImage)
+	}
+
+	adminHandler := handlers.NewAdminHandler()
+	admin := router.Group("/admin", middleware.AuthMiddleware(), middleware.RequireRole(models.RoleAdmin))
+	{
+		admin.PUT("/users/:id/role", adminHandler.ChangeUserRole)
+		admin.GET("/login-attempts", adminHandler.GetLoginAttempts)
 	}
 }
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+	"portfolio-server/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DeletionPolicyAnonymize = "anonymize"
+	DeletionPolicyCascade   = "cascade"
+)
+
+// ValidateAccountConfig rejects an unknown content deletion policy, so a typo fails at startup
+// instead of silently keeping the content of deleted accounts
+func ValidateAccountConfig(cfg *config.AccountConfig) error {
+	switch cfg.DeletionContentPolicy {
+	case DeletionPolicyAnonymize, DeletionPolicyCascade:
+		return nil
+	}
+	return fmt.Errorf("ACCOUNT_DELETION_CONTENT_POLICY must be %q or %q, got %q",
+		DeletionPolicyAnonymize, DeletionPolicyCascade, cfg.DeletionContentPolicy)
+}
+
+// deletedAuthorName is shown instead of the author of content left behind by a deleted account
+const deletedAuthorName = "탈퇴한 사용자"
+
+type AccountService struct {
+	db               *gorm.DB
+	emailChangeCodes *verificationCodes
+	reauthCodes      *verificationCodes
+	deletionPolicy   string
+}
+
+func NewAccountService() *AccountService {
+	cfg := config.LoadConfig()
+	return &AccountService{
+		db:               database.GetDB(),
+		emailChangeCodes: newVerificationCodes("email_change", cfg.Verification),
+		reauthCodes:      newVerificationCodes("reauth", cfg.Verification),
+		deletionPolicy:   cfg.Account.DeletionContentPolicy,
+	}
+}
+
+// ChangePasswordRequest is the request to change the password of the logged in user.
+// Accounts without a password (created with a social login) send Code from POST /auth/reauth instead of CurrentPassword.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	Code            string `json:"code" binding:"omitempty,len=6"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+// ChangeEmailRequest is the request to send a verification code to a new email
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email"`
+}
+
+// ConfirmEmailChangeRequest is the request to apply a new email with its verification code
+type ConfirmEmailChangeRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email"`
+	Code     string `json:"code" binding:"required,len=6"`
+}
+
+// ChangeRoleRequest is the request to change the role of a user
+type ChangeRoleRequest struct {
+	Role models.Role `json:"role" binding:"required"`
+}
+
+// DeleteAccountRequest is the request to delete the logged in user's account.
+// Like ChangePasswordRequest, accounts without a password send Code instead of Password.
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code" binding:"omitempty,len=6"`
+}
+
+func (s *AccountService) getUser(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFound()
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+func reauthSubject(userID uint) string {
+	return fmt.Sprintf("%d", userID)
+}
+
+// RequestReauthCode sends a verification code to the account's email. Accounts without a password
+// use it to confirm changing the password or deleting the account.
+func (s *AccountService) RequestReauthCode(userID uint, clientIP string) error {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.reauthCodes.Send(reauthSubject(userID), user.Email, clientIP, func(code string) error {
+		return utils.SendVerificationEmail(user.Email, code)
+	})
+}
+
+// confirmIdentity checks the password, or for accounts without one the code sent by RequestReauthCode
+func (s *AccountService) confirmIdentity(user *models.User, password, code, clientIP string) error {
+	if user.Password != "" {
+		if err := utils.CheckPassword(user.Password, password); err != nil {
+			return errors.ErrIncorrectPassword()
+		}
+		return nil
+	}
+
+	// 소셜 로그인으로 가입해 비밀번호가 없는 계정은 이메일 인증 코드로 확인합니다
+	if code == "" {
+		return errors.ErrReauthCodeRequired()
+	}
+	return s.reauthCodes.Verify(reauthSubject(user.ID), code, clientIP)
+}
+
+// ChangePassword replaces the password after checking the current one, or sets the first password of
+// an account without one after checking a verification code.
+// Every other session is logged out; the session making the request stays valid.
+func (s *AccountService) ChangePassword(userID uint, sessionID string, req *ChangePasswordRequest, clientIP string) error {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.confirmIdentity(user, req.CurrentPassword, req.Code, clientIP); err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return errors.NewAppError(500, "비밀번호 암호화에 실패했습니다", err.Error())
+	}
+
+	if err := s.db.Model(user).Update("password", hashedPassword).Error; err != nil {
+		return errors.NewAppError(500, "비밀번호 변경에 실패했습니다", err.Error())
+	}
+
+	if err := middleware.RevokeOtherSessions(context.Background(), userID, sessionID); err != nil {
+		return errors.NewAppError(500, "세션 종료에 실패했습니다", err.Error())
+	}
+
+	return nil
+}
+
+func emailChangeSubject(userID uint, email string) string {
+	return fmt.Sprintf("%d:%s", userID, email)
+}
+
+// RequestEmailChange sends a verification code to the new email address
+func (s *AccountService) RequestEmailChange(userID uint, newEmail, clientIP string) error {
+	if _, err := s.getUser(userID); err != nil {
+		return err
+	}
+
+	var existingUser models.User
+	if err := s.db.Where("email = ?", newEmail).First(&existingUser).Error; err == nil {
+		return errors.ErrEmailAlreadyExists()
+	}
+
+	return s.emailChangeCodes.Send(emailChangeSubject(userID, newEmail), newEmail, clientIP, func(code string) error {
+		return utils.SendVerificationEmail(newEmail, code)
+	})
+}
+
+// ConfirmEmailChange verifies the code sent to the new address and switches the account to it
+func (s *AccountService) ConfirmEmailChange(userID uint, req *ConfirmEmailChangeRequest, clientIP string) (*models.User, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.emailChangeCodes.Verify(emailChangeSubject(userID, req.NewEmail), req.Code, clientIP); err != nil {
+		return nil, err
+	}
+
+	var existingUser models.User
+	if err := s.db.Where("email = ?", req.NewEmail).First(&existingUser).Error; err == nil {
+		return nil, errors.ErrEmailAlreadyExists()
+	}
+
+	verifiedAt := time.Now()
+	if err := s.db.Model(user).Updates(map[string]interface{}{
+		"email":             req.NewEmail,
+		"email_verified_at": verifiedAt,
+	}).Error; err != nil {
+		return nil, errors.NewAppError(500, "이메일 변경에 실패했습니다", err.Error())
+	}
+	user.Email = req.NewEmail
+	user.EmailVerifiedAt = &verifiedAt
+
+	return user, nil
+}
+
+// DeleteAccount soft-deletes the user and handles their articles and comments
+// according to the configured deletion policy.
+func (s *AccountService) DeleteAccount(userID uint, req *DeleteAccountRequest, clientIP string) error {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.confirmIdentity(user, req.Password, req.Code, clientIP); err != nil {
+		return err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 읽기 목록은 본인만 보는 데이터이므로 정책과 관계없이 지웁니다
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Bookmark{}).Error; err != nil {
+			return fmt.Errorf("북마크 삭제 실패: %w", err)
+		}
+
+		if s.deletionPolicy == DeletionPolicyCascade {
+			// 개수는 지운 댓글과 반응이 달려 있던 글과 댓글만 다시 셉니다
+			var commentedArticles []uint
+			if err := tx.Model(&models.Comment{}).Where("author_id = ?", userID).
+				Distinct().Pluck("article_id", &commentedArticles).Error; err != nil {
+				return fmt.Errorf("댓글 조회 실패: %w", err)
+			}
+			var reactedArticles, reactedComments []uint
+			if err := tx.Model(&models.Reaction{}).Where("user_id = ? AND target_type = ?", userID, models.ReactionTargetArticle).
+				Distinct().Pluck("target_id", &reactedArticles).Error; err != nil {
+				return fmt.Errorf("반응 조회 실패: %w", err)
+			}
+			if err := tx.Model(&models.Reaction{}).Where("user_id = ? AND target_type = ?", userID, models.ReactionTargetComment).
+				Distinct().Pluck("target_id", &reactedComments).Error; err != nil {
+				return fmt.Errorf("반응 조회 실패: %w", err)
+			}
+
+			// 사용자가 남긴 반응과 사용자의 댓글에 달린 반응도 함께 지웁니다
+			if err := tx.Where("user_id = ? OR (target_type = ? AND target_id IN (?))", userID, models.ReactionTargetComment,
+				tx.Model(&models.Comment{}).Select("id").Where("author_id = ?", userID)).
+				Delete(&models.Reaction{}).Error; err != nil {
+				return fmt.Errorf("반응 삭제 실패: %w", err)
+			}
+			if err := tx.Where("author_id = ?", userID).Delete(&models.Comment{}).Error; err != nil {
+				return fmt.Errorf("댓글 삭제 실패: %w", err)
+			}
+			if err := database.RecountComments(tx, commentedArticles); err != nil {
+				return fmt.Errorf("댓글 수 갱신 실패: %w", err)
+			}
+			if err := database.RecountReactions(tx, models.ReactionTargetArticle, reactedArticles); err != nil {
+				return fmt.Errorf("반응 수 갱신 실패: %w", err)
+			}
+			if err := database.RecountReactions(tx, models.ReactionTargetComment, reactedComments); err != nil {
+				return fmt.Errorf("반응 수 갱신 실패: %w", err)
+			}
+			if err := tx.Where("author_id = ?", userID).Delete(&models.Article{}).Error; err != nil {
+				return fmt.Errorf("게시글 삭제 실패: %w", err)
+			}
+		}
+
+		// 탈퇴한 계정의 이메일과 사용자명으로 다시 가입할 수 있도록 고유 값을 비웁니다
+		if err := tx.Model(user).Updates(map[string]interface{}{
+			"email":             fmt.Sprintf("deleted-%d@deleted.invalid", userID),
+			"username":          fmt.Sprintf("deleted_user_%d", userID),
+			"password":          "",
+			"email_verified_at": nil,
+		}).Error; err != nil {
+			return fmt.Errorf("사용자 정보 삭제 실패: %w", err)
+		}
+
+		if err := tx.Delete(user).Error; err != nil {
+			return fmt.Errorf("사용자 삭제 실패: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.NewAppError(500, "회원 탈퇴에 실패했습니다", err.Error())
+	}
+
+	if err := middleware.RevokeAllSessions(context.Background(), userID); err != nil {
+		return errors.NewAppError(500, "세션 종료에 실패했습니다", err.Error())
+	}
+
+	return nil
+}
+
+// ChangeRole sets the role of another user. The user's sessions are revoked so the new role
+// applies right away instead of when their current access token expires.
+func (s *AccountService) ChangeRole(actor policy.Actor, targetUserID uint, role models.Role) (*models.User, error) {
+	if !policy.CanManageUsers(actor) {
+		return nil, errors.ErrPermissionDenied()
+	}
+	if !role.IsValid() {
+		return nil, errors.ErrInvalidInput("role은 admin, editor, author, reader 중 하나여야 합니다")
+	}
+	if actor.UserID == targetUserID {
+		return nil, errors.ErrInvalidInput("자신의 역할은 변경할 수 없습니다")
+	}
+
+	user, err := s.getUser(targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	if err := s.db.Model(user).Update("role", role).Error; err != nil {
+		return nil, errors.NewAppError(500, "역할 변경에 실패했습니다", err.Error())
+	}
+	user.Role = role
+
+	if err := middleware.RevokeAllSessions(context.Background(), user.ID); err != nil {
+		return nil, errors.NewAppError(500, "세션 종료에 실패했습니다", err.Error())
+	}
+
+	return user, nil
+}
+
+// authorName returns the name to show for content whose author may have deleted their account
+func authorName(author models.User) string {
+	if author.ID == 0 {
+		return deletedAuthorName
+	}
+	return author.Username
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAPIKeyExpirationDays = 90
+	maxAPIKeysPerUser           = 20
+)
+
+type APIKeyService struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyService() *APIKeyService {
+	return &APIKeyService{
+		db: database.GetDB(),
+	}
+}
+
+// CreateAPIKeyRequest is the request to create a personal access token
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,min=1,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=365"`
+}
+
+func toAPIKeyResponse(key *models.APIKey) models.APIKeyResponse {
+	return models.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.ScopeList(),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// CreateAPIKey mints a new key for the user. The plain key is returned only here.
+func (s *APIKeyService) CreateAPIKey(userID uint, req *CreateAPIKeyRequest) (*models.CreatedAPIKeyResponse, error) {
+	for _, scope := range req.Scopes {
+		if !isAPIKeyScope(scope) {
+			return nil, errors.ErrInvalidInput(fmt.Sprintf("알 수 없는 scope입니다: %s (사용 가능: %s)", scope, strings.Join(models.APIKeyScopes, ", ")))
+		}
+	}
+
+	var count int64
+	if err := s.db.Model(&models.APIKey{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("API 키 조회 실패: %w", err)
+	}
+	if count >= maxAPIKeysPerUser {
+		return nil, errors.ErrInvalidInput(fmt.Sprintf("API 키는 최대 %d개까지 만들 수 있습니다", maxAPIKeysPerUser))
+	}
+
+	secret, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, errors.NewAppError(500, "API 키 생성에 실패했습니다", err.Error())
+	}
+	rawKey := middleware.APIKeyPrefix + secret
+
+	expiresInDays := req.ExpiresInDays
+	if expiresInDays == 0 {
+		expiresInDays = defaultAPIKeyExpirationDays
+	}
+
+	key := models.APIKey{
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    rawKey[:len(middleware.APIKeyPrefix)+8],
+		KeyHash:   utils.HashToken(rawKey),
+		Scopes:    strings.Join(uniqueStrings(req.Scopes), " "),
+		ExpiresAt: time.Now().AddDate(0, 0, expiresInDays),
+	}
+
+	if err := s.db.Create(&key).Error; err != nil {
+		return nil, fmt.Errorf("API 키 생성 실패: %w", err)
+	}
+
+	return &models.CreatedAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(&key),
+		Key:            rawKey,
+	}, nil
+}
+
+// ListAPIKeys returns the user's keys that have not been revoked
+func (s *APIKeyService) ListAPIKeys(userID uint) ([]models.APIKeyResponse, error) {
+	var keys []models.APIKey
+	if err := s.db.Where("user_id = ?", userID).Order("id DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("API 키 목록 조회 실패: %w", err)
+	}
+
+	responses := make([]models.APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = toAPIKeyResponse(&keys[i])
+	}
+
+	return responses, nil
+}
+
+// RevokeAPIKey revokes one of the user's keys
+func (s *APIKeyService) RevokeAPIKey(userID, keyID uint) error {
+	result := s.db.Where("id = ? AND user_id = ?", keyID, userID).Delete(&models.APIKey{})
+	if result.Error != nil {
+		return fmt.Errorf("API 키 삭제 실패: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrAPIKeyNotFound()
+	}
+
+	return nil
+}
+
+func isAPIKeyScope(scope string) bool {
+	for _, s := range models.APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/utils"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// 요약의 최대 길이 (문자 수)
+const articleExcerptLength = 200
+
+// renderArticleContent derives everything that is computed from the Markdown content: the sanitized
+// HTML with its table of contents, and the excerpt, word count and reading time shown in lists.
+// It runs whenever the content is saved so reads never have to render or load the content.
+func renderArticleContent(article *models.Article) error {
+	contentHTML, toc, err := utils.RenderMarkdown(article.Content)
+	if err != nil {
+		return fmt.Errorf("본문 렌더링 실패: %w", err)
+	}
+	article.ContentHTML = contentHTML
+	article.TOC = toc
+
+	text := utils.PlainText(article.Content)
+	article.Excerpt = utils.Excerpt(text, articleExcerptLength)
+	article.WordCount = utils.CountWords(text)
+	article.ReadingTime = utils.ReadingTime(article.WordCount)
+	return nil
+}
+
+// BackfillArticleContent renders the articles written before rendering existed
+func BackfillArticleContent() error {
+	db := database.GetDB()
+
+	var articles []models.Article
+	total := 0
+	err := db.Unscoped().Where("content_html = ''").
+		FindInBatches(&articles, 100, func(tx *gorm.DB, batch int) error {
+			for i := range articles {
+				article := &articles[i]
+				if err := renderArticleContent(article); err != nil {
+					return fmt.Errorf("failed to render article %d: %w", article.ID, err)
+				}
+				if article.ContentHTML == "" {
+					continue
+				}
+				err := db.Unscoped().Model(article).
+					Select("content_html", "toc", "excerpt", "word_count", "reading_time").
+					UpdateColumns(article).Error
+				if err != nil {
+					return fmt.Errorf("failed to save rendered article %d: %w", article.ID, err)
+				}
+				total++
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		log.Printf("Rendered %d article(s)", total)
+	}
+	return nil
+}
+
+// articleListField is a field that GET /articles can return for each article
+type articleListField struct {
+	name string
+	// 이 필드에 필요한 연관 데이터
+	preload string
+	// 보는 사람에 따라 달라지는 필드는 fields=로 요청했을 때만 보냅니다
+	optIn bool
+	value func(article *models.Article, viewer *articleListViewer) interface{}
+}
+
+// articleListViewer holds what list items need to know about the viewer, loaded once per page
+type articleListViewer struct {
+	bookmarked map[uint]bool
+}
+
+// articleListFields are the fields of a list item, the same as ArticleResponse without content and viewer_reactions
+var articleListFields = []articleListField{
+	{name: "id", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.ID }},
+	{name: "title", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.Title }},
+	{name: "slug", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.Slug }},
+	{name: "excerpt", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.Excerpt }},
+	{name: "word_count", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.WordCount }},
+	{name: "reading_time", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.ReadingTime }},
+	{name: "author_id", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.AuthorID }},
+	{name: "author_name", preload: "Author", value: func(a *models.Article, _ *articleListViewer) interface{} { return authorName(a.Author) }},
+	{name: "view_count", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.ViewCount }},
+	{name: "comment_count", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.CommentCount }},
+	{name: "reactions", value: func(a *models.Article, _ *articleListViewer) interface{} { return reactionCounts(a.ReactionCounts) }},
+	{name: "status", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.Status }},
+	{name: "published_at", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.PublishedAt }},
+	{name: "categories", preload: "Categories", value: func(a *models.Article, _ *articleListViewer) interface{} { return categoryInfos(a.Categories) }},
+	{name: "created_at", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.CreatedAt }},
+	{name: "updated_at", value: func(a *models.Article, _ *articleListViewer) interface{} { return a.UpdatedAt }},
+	{name: "is_bookmarked", optIn: true, value: func(a *models.Article, v *articleListViewer) interface{} { return v.bookmarked[a.ID] }},
+}
+
+// articleListColumns are loaded for list items. The content is left out on purpose;
+// the other columns are small and also needed for cursors.
+var articleListColumns = []string{
+	"id", "title", "slug", "excerpt", "word_count", "reading_time", "author_id",
+	"view_count", "comment_count", "reaction_counts", "status", "published_at", "created_at", "updated_at",
+}
+
+// defaultArticleFields are sent when fields= is empty: every field except the opt-in ones
+var defaultArticleFields = func() []articleListField {
+	fields := make([]articleListField, 0, len(articleListFields))
+	for _, field := range articleListFields {
+		if !field.optIn {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}()
+
+// parseArticleFields resolves a comma separated fields= value. Empty means every field that is not opt-in.
+func parseArticleFields(value string) ([]articleListField, error) {
+	if strings.TrimSpace(value) == "" {
+		return defaultArticleFields, nil
+	}
+
+	requested := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		requested[name] = true
+	}
+
+	fields := make([]articleListField, 0, len(requested))
+	for _, field := range articleListFields {
+		if requested[field.name] {
+			fields = append(fields, field)
+			delete(requested, field.name)
+		}
+	}
+	for name := range requested {
+		return nil, errors.ErrInvalidInput(fmt.Sprintf("알 수 없는 필드입니다: %s", name))
+	}
+	if len(fields) == 0 {
+		return defaultArticleFields, nil
+	}
+
+	return fields, nil
+}
+
+// preloadArticleFields loads only the associations the requested fields need
+func preloadArticleFields(query *gorm.DB, fields []articleListField) *gorm.DB {
+	for _, field := range fields {
+		switch field.preload {
+		case "Author":
+			query = query.Preload("Author", func(db *gorm.DB) *gorm.DB {
+				return db.Select("id", "username")
+			})
+		case "Categories":
+			query = query.Preload("Categories")
+		}
+	}
+	return query
+}
+
+// loadArticleListViewer loads the viewer's state for the articles of a page, only for the requested fields
+func loadArticleListViewer(db *gorm.DB, userID uint, fields []articleListField, articleIDs []uint) (*articleListViewer, error) {
+	viewer := &articleListViewer{bookmarked: make(map[uint]bool)}
+	if userID == 0 || len(articleIDs) == 0 || !hasArticleField(fields, "is_bookmarked") {
+		return viewer, nil
+	}
+
+	var bookmarked []uint
+	if err := db.Model(&models.Bookmark{}).
+		Where("user_id = ? AND article_id IN ?", userID, articleIDs).
+		Pluck("article_id", &bookmarked).Error; err != nil {
+		return nil, fmt.Errorf("북마크 조회 실패: %w", err)
+	}
+	for _, id := range bookmarked {
+		viewer.bookmarked[id] = true
+	}
+	return viewer, nil
+}
+
+func hasArticleField(fields []articleListField, name string) bool {
+	for _, field := range fields {
+		if field.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func newArticleListItem(article *models.Article, fields []articleListField, viewer *articleListViewer) map[string]interface{} {
+	item := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		item[field.name] = field.value(article, viewer)
+	}
+	return item
+}
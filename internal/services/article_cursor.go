@@ -0,0 +1,106 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/models"
+	"time"
+)
+
+// 글 목록 정렬 기준
+const (
+	ArticleSortNewest   = "newest"
+	ArticleSortOldest   = "oldest"
+	ArticleSortViews    = "views"
+	ArticleSortComments = "comments"
+)
+
+// articleDateColumn is the date articles are sorted and filtered by: when they were published,
+// or written for articles that are not published yet
+const articleDateColumn = "COALESCE(articles.published_at, articles.created_at)"
+
+// articleSort orders by column and then by ID, so that every position in the list is unique
+type articleSort struct {
+	column string
+	desc   bool
+}
+
+var articleSorts = map[string]articleSort{
+	ArticleSortNewest:   {column: articleDateColumn, desc: true},
+	ArticleSortOldest:   {column: articleDateColumn, desc: false},
+	ArticleSortViews:    {column: "articles.view_count", desc: true},
+	ArticleSortComments: {column: "articles.comment_count", desc: true},
+}
+
+func (o articleSort) orderBy() string {
+	if o.desc {
+		return o.column + " DESC, articles.id DESC"
+	}
+	return o.column + " ASC, articles.id ASC"
+}
+
+// after is the condition for the rows that come after the cursor (sort value, id)
+func (o articleSort) after() string {
+	if o.desc {
+		return "(" + o.column + ", articles.id) < (?, ?)"
+	}
+	return "(" + o.column + ", articles.id) > (?, ?)"
+}
+
+// articleCursor is the position of the last article of a page. It is sent to clients as
+// base64 encoded JSON so that they treat it as opaque.
+type articleCursor struct {
+	Sort  string     `json:"s"`
+	Date  *time.Time `json:"d,omitempty"`
+	Count int        `json:"c,omitempty"`
+	ID    uint       `json:"id"`
+}
+
+func (c *articleCursor) value(sort string) interface{} {
+	switch sort {
+	case ArticleSortViews, ArticleSortComments:
+		return c.Count
+	}
+	return *c.Date
+}
+
+func encodeArticleCursor(article *models.Article, sort string) (string, error) {
+	cursor := articleCursor{Sort: sort, ID: article.ID}
+	switch sort {
+	case ArticleSortViews:
+		cursor.Count = article.ViewCount
+	case ArticleSortComments:
+		cursor.Count = article.CommentCount
+	default:
+		date := article.CreatedAt
+		if article.PublishedAt != nil {
+			date = *article.PublishedAt
+		}
+		cursor.Date = &date
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeArticleCursor parses a cursor and checks that it was issued for the same sort order
+func decodeArticleCursor(value, sort string) (*articleCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.ErrInvalidCursor()
+	}
+
+	var cursor articleCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort || cursor.ID == 0 {
+		return nil, errors.ErrInvalidCursor()
+	}
+	if (sort == ArticleSortNewest || sort == ArticleSortOldest) && cursor.Date == nil {
+		return nil, errors.ErrInvalidCursor()
+	}
+
+	return &cursor, nil
+}
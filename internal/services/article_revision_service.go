@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+	"portfolio-server/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ArticleRevisionService struct {
+	db    *gorm.DB
+	slugs *articleSlugs
+}
+
+func NewArticleRevisionService() *ArticleRevisionService {
+	return &ArticleRevisionService{
+		db:    database.GetDB(),
+		slugs: newArticleSlugs(config.LoadConfig().Article),
+	}
+}
+
+// recordRevision stores the article's current title and content as its next revision.
+// previous is the article before the change; for articles written before revisions existed
+// it is stored first so the original version can still be restored.
+// The article row is locked first, so concurrent edits of the same article number their revisions one
+// after the other instead of both taking MAX(revision)+1.
+func recordRevision(tx *gorm.DB, article *models.Article, previous *models.Article, editorID uint, restoredFrom *int) error {
+	var locked []uint
+	if err := tx.Model(&models.Article{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", article.ID).
+		Pluck("id", &locked).Error; err != nil {
+		return err
+	}
+
+	var latest int
+	if err := tx.Model(&models.ArticleRevision{}).
+		Where("article_id = ?", article.ID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&latest).Error; err != nil {
+		return err
+	}
+
+	if latest == 0 && previous != nil {
+		latest = 1
+		if err := tx.Create(&models.ArticleRevision{
+			ArticleID: article.ID,
+			Revision:  latest,
+			Title:     previous.Title,
+			Content:   previous.Content,
+			EditorID:  previous.AuthorID,
+			CreatedAt: previous.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Create(&models.ArticleRevision{
+		ArticleID:    article.ID,
+		Revision:     latest + 1,
+		Title:        article.Title,
+		Content:      article.Content,
+		EditorID:     editorID,
+		RestoredFrom: restoredFrom,
+	}).Error
+}
+
+func newArticleRevisionResponse(revision *models.ArticleRevision, withContent bool) models.ArticleRevisionResponse {
+	response := models.ArticleRevisionResponse{
+		Revision:     revision.Revision,
+		Title:        revision.Title,
+		EditorID:     revision.EditorID,
+		EditorName:   authorName(revision.Editor),
+		RestoredFrom: revision.RestoredFrom,
+		CreatedAt:    revision.CreatedAt,
+	}
+	if withContent {
+		response.Content = revision.Content
+	}
+	return response
+}
+
+// editableArticle loads the article and checks that the actor may see and change its history
+func (s *ArticleRevisionService) editableArticle(articleID uint, actor policy.Actor) (*models.Article, error) {
+	var article models.Article
+	if err := s.db.First(&article, articleID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrArticleNotFound()
+		}
+		return nil, fmt.Errorf("게시글 조회 실패: %w", err)
+	}
+
+	if !policy.CanEditArticle(actor, &article) {
+		return nil, errors.ErrPermissionDenied()
+	}
+
+	return &article, nil
+}
+
+func (s *ArticleRevisionService) findRevision(articleID uint, revision int) (*models.ArticleRevision, error) {
+	var found models.ArticleRevision
+	if err := s.db.Preload("Editor").
+		Where("article_id = ? AND revision = ?", articleID, revision).
+		First(&found).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRevisionNotFound()
+		}
+		return nil, fmt.Errorf("버전 조회 실패: %w", err)
+	}
+	return &found, nil
+}
+
+// ListRevisions returns the article's revisions, newest first, without their content
+func (s *ArticleRevisionService) ListRevisions(articleID uint, lastRevision *int, limit int, actor policy.Actor) (*models.ArticleRevisionListResponse, error) {
+	if _, err := s.editableArticle(articleID, actor); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > 50 {
+		limit = 20
+	}
+
+	query := s.db.Preload("Editor").Where("article_id = ?", articleID)
+	if lastRevision != nil && *lastRevision > 0 {
+		query = query.Where("revision < ?", *lastRevision)
+	}
+
+	var revisions []models.ArticleRevision
+	if err := query.Order("revision DESC").Limit(limit + 1).Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("버전 목록 조회 실패: %w", err)
+	}
+
+	hasMore := len(revisions) > limit
+	if hasMore {
+		revisions = revisions[:limit]
+	}
+
+	responses := make([]models.ArticleRevisionResponse, len(revisions))
+	for i := range revisions {
+		responses[i] = newArticleRevisionResponse(&revisions[i], false)
+	}
+
+	var nextCursor *int
+	if hasMore && len(revisions) > 0 {
+		last := revisions[len(revisions)-1].Revision
+		nextCursor = &last
+	}
+
+	return &models.ArticleRevisionListResponse{
+		Revisions:  responses,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+// GetRevision returns a single revision including its content
+func (s *ArticleRevisionService) GetRevision(articleID uint, revision int, actor policy.Actor) (*models.ArticleRevisionResponse, error) {
+	if _, err := s.editableArticle(articleID, actor); err != nil {
+		return nil, err
+	}
+
+	found, err := s.findRevision(articleID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newArticleRevisionResponse(found, true)
+	return &response, nil
+}
+
+// DiffRevisions compares the content of two revisions line by line
+func (s *ArticleRevisionService) DiffRevisions(articleID uint, from, to int, actor policy.Actor) (*models.ArticleRevisionDiffResponse, error) {
+	if _, err := s.editableArticle(articleID, actor); err != nil {
+		return nil, err
+	}
+
+	oldRevision, err := s.findRevision(articleID, from)
+	if err != nil {
+		return nil, err
+	}
+	newRevision, err := s.findRevision(articleID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := utils.DiffLines(utils.SplitLines(oldRevision.Content), utils.SplitLines(newRevision.Content))
+
+	response := &models.ArticleRevisionDiffResponse{
+		ArticleID: articleID,
+		From:      from,
+		To:        to,
+		OldTitle:  oldRevision.Title,
+		NewTitle:  newRevision.Title,
+		Lines:     make([]models.DiffLine, len(diff)),
+	}
+	for i, line := range diff {
+		response.Lines[i] = models.DiffLine{Op: line.Op, OldLine: line.OldLine, NewLine: line.NewLine, Text: line.Text}
+		switch line.Op {
+		case utils.DiffInsert:
+			response.Added++
+		case utils.DiffDelete:
+			response.Removed++
+		}
+	}
+
+	return response, nil
+}
+
+// RestoreRevision sets the article's title and content back to a revision.
+// The restore is itself recorded as a new revision, so it can be undone as well.
+func (s *ArticleRevisionService) RestoreRevision(articleID uint, revision int, actor policy.Actor) (*models.ArticleResponse, error) {
+	article, err := s.editableArticle(articleID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := s.findRevision(articleID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *article
+	article.Title = found.Title
+	article.Content = found.Content
+	if err := renderArticleContent(article); err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if article.Title != previous.Title {
+			if err := s.slugs.assign(tx, article); err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit(articleCounterColumns...).Save(article).Error; err != nil {
+			return err
+		}
+		return recordRevision(tx, article, &previous, actor.UserID, &found.Revision)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("버전 복원 실패: %w", err)
+	}
+
+	if err := s.db.Preload("Author").Preload("Categories").First(article, article.ID).Error; err != nil {
+		return nil, fmt.Errorf("게시글 로드 실패: %w", err)
+	}
+	syncViewRanking(context.Background(), article)
+
+	return newViewerArticleResponse(s.db, article, actor.UserID)
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"log"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ArticleScheduler periodically publishes scheduled articles whose time has come.
+// Reads already treat those articles as public (see publicArticles); the scheduler makes the
+// stored status match so that it is also correct for anything reading the table directly.
+type ArticleScheduler struct {
+	db       *gorm.DB
+	interval time.Duration
+}
+
+func NewArticleScheduler() *ArticleScheduler {
+	cfg := config.LoadConfig()
+	interval := time.Duration(cfg.Article.SchedulerIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &ArticleScheduler{
+		db:       database.GetDB(),
+		interval: interval,
+	}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled
+func (s *ArticleScheduler) Start(ctx context.Context) {
+	runEvery(ctx, s.interval, func(ctx context.Context) {
+		if count, err := s.PublishDue(time.Now()); err != nil {
+			log.Printf("Failed to publish scheduled articles: %v", err)
+		} else if count > 0 {
+			log.Printf("Published %d scheduled article(s)", count)
+		}
+	})
+}
+
+// PublishDue marks every scheduled article with PublishedAt at or before now as published
+func (s *ArticleScheduler) PublishDue(now time.Time) (int64, error) {
+	result := s.db.Model(&models.Article{}).
+		Where("status = ? AND published_at <= ?", models.ArticleStatusScheduled, now).
+		Update("status", models.ArticleStatusPublished)
+	return result.RowsAffected, result.Error
+}
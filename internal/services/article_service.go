@@ -1,21 +1,31 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"portfolio-server/internal/config"
 	"portfolio-server/internal/database"
 	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
 	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type ArticleService struct {
-	db *gorm.DB
+	db    *gorm.DB
+	slugs *articleSlugs
+	views *viewCounter
 }
 
 func NewArticleService() *ArticleService {
+	cfg := config.LoadConfig()
 	return &ArticleService{
-		db: database.GetDB(),
+		db:    database.GetDB(),
+		slugs: newArticleSlugs(cfg.Article),
+		views: newViewCounter(cfg.View, cfg.Server.FrontendURL),
 	}
 }
 
@@ -23,22 +33,143 @@ type CreateArticleRequest struct {
 	Title       string `json:"title" binding:"required,min=1,max=200"`
 	Content     string `json:"content" binding:"required,min=1"`
 	CategoryIDs []uint `json:"category_ids"`
+	// 생략하면 바로 공개됩니다. scheduled는 published_at이 필요합니다
+	Status      models.ArticleStatus `json:"status" binding:"omitempty,oneof=draft published scheduled"`
+	PublishedAt *time.Time           `json:"published_at"`
+}
+
+// ArticleListQuery is the query string of GET /articles.
+// category_id may be repeated to match articles in any of the categories; from and to are inclusive dates.
+// fields is a comma separated list of the fields each article should have, e.g. "id,title,excerpt".
+type ArticleListQuery struct {
+	Cursor      string               `form:"cursor"`
+	Limit       int                  `form:"limit"`
+	Sort        string               `form:"sort" binding:"omitempty,oneof=newest oldest views comments"`
+	Status      models.ArticleStatus `form:"status" binding:"omitempty,oneof=draft published scheduled archived"`
+	CategoryIDs []uint               `form:"category_id"`
+	AuthorID    *uint                `form:"author_id"`
+	From        *time.Time           `form:"from" time_format:"2006-01-02"`
+	To          *time.Time           `form:"to" time_format:"2006-01-02"`
+	Fields      string               `form:"fields"`
 }
 
 type UpdateArticleRequest struct {
 	Title       string `json:"title" binding:"required,min=1,max=200"`
 	Content     string `json:"content" binding:"required,min=1"`
 	CategoryIDs []uint `json:"category_ids"`
+	// 생략하면 현재 상태를 유지합니다
+	Status      models.ArticleStatus `json:"status" binding:"omitempty,oneof=draft published scheduled archived"`
+	PublishedAt *time.Time           `json:"published_at"`
 }
 
-func (s *ArticleService) CreateArticle(req *CreateArticleRequest, authorID uint) (*models.Article, error) {
+// publicArticleCondition matches the articles everyone may read, see models.Article.IsPublic.
+// The only parameter is the current time.
+const publicArticleCondition = "(articles.status = 'published' OR (articles.status = 'scheduled' AND articles.published_at <= ?))"
+
+// articleCounterColumns are changed in place by views, comments and reactions.
+// Saving an article loaded earlier leaves them out so it cannot write back stale counts.
+var articleCounterColumns = []string{"view_count", "comment_count", "reaction_counts"}
+
+// publicArticles limits a query to the articles everyone may read
+func publicArticles(now time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(publicArticleCondition, now)
+	}
+}
+
+// applyStatus moves the article to the requested status and sets PublishedAt accordingly
+func applyStatus(article *models.Article, status models.ArticleStatus, publishedAt *time.Time, now time.Time) error {
+	switch status {
+	case "":
+		return nil
+	case models.ArticleStatusPublished:
+		// 이미 공개된 글을 다시 저장해도 처음 공개된 시각은 유지합니다
+		if !article.IsPublic(now) || article.PublishedAt == nil {
+			article.PublishedAt = &now
+		}
+	case models.ArticleStatusScheduled:
+		if publishedAt == nil || !publishedAt.After(now) {
+			return errors.ErrInvalidInput("예약 공개하려면 현재 이후의 published_at이 필요합니다")
+		}
+		at := publishedAt.UTC()
+		article.PublishedAt = &at
+	case models.ArticleStatusDraft:
+		article.PublishedAt = nil
+	case models.ArticleStatusArchived:
+		// 보관된 글은 처음 공개된 시각을 유지합니다
+	default:
+		return errors.ErrInvalidInput("status는 draft, published, scheduled, archived 중 하나여야 합니다")
+	}
+
+	article.Status = status
+	return nil
+}
+
+func categoryInfos(categories []models.Category) []models.CategoryInfo {
+	infos := make([]models.CategoryInfo, len(categories))
+	for i, cat := range categories {
+		infos[i] = models.CategoryInfo{ID: cat.ID, Name: cat.Name}
+	}
+	return infos
+}
+
+func newArticleResponse(article *models.Article) models.ArticleResponse {
+	return models.ArticleResponse{
+		ID:              article.ID,
+		Title:           article.Title,
+		Slug:            article.Slug,
+		Content:         article.Content,
+		ContentHTML:     article.ContentHTML,
+		TOC:             article.TOC,
+		Excerpt:         article.Excerpt,
+		WordCount:       article.WordCount,
+		ReadingTime:     article.ReadingTime,
+		AuthorID:        article.AuthorID,
+		AuthorName:      authorName(article.Author),
+		ViewCount:       article.ViewCount,
+		CommentCount:    article.CommentCount,
+		Reactions:       reactionCounts(article.ReactionCounts),
+		ViewerReactions: []string{},
+		Status:          article.Status,
+		PublishedAt:     article.PublishedAt,
+		Categories:      categoryInfos(article.Categories),
+		CreatedAt:       article.CreatedAt,
+		UpdatedAt:       article.UpdatedAt,
+	}
+}
+
+func (s *ArticleService) CreateArticle(req *CreateArticleRequest, actor policy.Actor) (*models.ArticleResponse, error) {
+	if !policy.CanCreateArticle(actor) {
+		return nil, errors.ErrPermissionDenied()
+	}
+
 	article := models.Article{
 		Title:    req.Title,
 		Content:  req.Content,
-		AuthorID: authorID,
+		AuthorID: actor.UserID,
+	}
+	if err := renderArticleContent(&article); err != nil {
+		return nil, err
+	}
+
+	status := req.Status
+	if status == "" {
+		status = models.ArticleStatusPublished
+	}
+	if err := applyStatus(&article, status, req.PublishedAt, time.Now()); err != nil {
+		return nil, err
 	}
 
-	if err := s.db.Create(&article).Error; err != nil {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.slugs.assign(tx, &article); err != nil {
+			return err
+		}
+		if err := tx.Create(&article).Error; err != nil {
+			return err
+		}
+		return recordRevision(tx, &article, nil, actor.UserID, nil)
+	})
+	if err != nil {
 		return nil, fmt.Errorf("게시글 생성 실패: %w", err)
 	}
 
@@ -57,10 +188,12 @@ func (s *ArticleService) CreateArticle(req *CreateArticleRequest, authorID uint)
 		return nil, fmt.Errorf("failed to load article: %w", err)
 	}
 
-	return &article, nil
+	return newViewerArticleResponse(s.db, &article, actor.UserID)
 }
 
-func (s *ArticleService) GetArticleByID(id uint) (*models.ArticleResponse, error) {
+// GetArticleByID returns a public article, or an unpublished one to its author and staff.
+// Other viewers get ErrArticleNotFound so unpublished articles stay hidden.
+func (s *ArticleService) GetArticleByID(id uint, viewer policy.Actor, client middleware.ClientInfo) (*models.ArticleResponse, error) {
 	var article models.Article
 	if err := s.db.Preload("Author").Preload("Categories").First(&article, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -69,41 +202,140 @@ func (s *ArticleService) GetArticleByID(id uint) (*models.ArticleResponse, error
 		return nil, fmt.Errorf("게시글 조회 실패: %w", err)
 	}
 
-	s.db.Model(&article).Update("view_count", gorm.Expr("view_count + ?", 1))
-	article.ViewCount++
+	return s.viewArticle(&article, viewer, client)
+}
+
+// GetArticleBySlug returns the article like GetArticleByID. When slug is one the article used
+// before its title changed, the article's current slug is returned instead so the caller can redirect.
+func (s *ArticleService) GetArticleBySlug(slug string, viewer policy.Actor, client middleware.ClientInfo) (*models.ArticleResponse, string, error) {
+	var article models.Article
+	err := s.db.Preload("Author").Preload("Categories").Where("slug = ?", slug).First(&article).Error
+	if err == nil {
+		response, err := s.viewArticle(&article, viewer, client)
+		return response, "", err
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, "", fmt.Errorf("게시글 조회 실패: %w", err)
+	}
+
+	var previous models.ArticleSlug
+	if err := s.db.Where("slug = ?", slug).First(&previous).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, "", errors.ErrArticleNotFound()
+		}
+		return nil, "", fmt.Errorf("게시글 조회 실패: %w", err)
+	}
+
+	if err := s.db.First(&article, previous.ArticleID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, "", errors.ErrArticleNotFound()
+		}
+		return nil, "", fmt.Errorf("게시글 조회 실패: %w", err)
+	}
+	// 볼 수 없는 글의 새 주소가 드러나지 않도록 합니다
+	if !policy.CanViewArticle(viewer, &article, time.Now()) {
+		return nil, "", errors.ErrArticleNotFound()
+	}
+
+	return nil, article.Slug, nil
+}
 
-	categories := make([]models.CategoryInfo, len(article.Categories))
-	for i, cat := range article.Categories {
-		categories[i] = models.CategoryInfo{ID: cat.ID, Name: cat.Name}
+// viewArticle checks that the viewer may see the article and counts the view
+func (s *ArticleService) viewArticle(article *models.Article, viewer policy.Actor, client middleware.ClientInfo) (*models.ArticleResponse, error) {
+	now := time.Now()
+	if !policy.CanViewArticle(viewer, article, now) {
+		return nil, errors.ErrArticleNotFound()
 	}
 
-	response := &models.ArticleResponse{
-		ID:         article.ID,
-		Title:      article.Title,
-		Content:    article.Content,
-		AuthorID:   article.AuthorID,
-		AuthorName: article.Author.Username,
-		ViewCount:  article.ViewCount,
-		Categories: categories,
-		CreatedAt:  article.CreatedAt,
-		UpdatedAt:  article.UpdatedAt,
+	// 공개 전 미리보기는 조회수에 포함하지 않습니다
+	if article.IsPublic(now) {
+		// 아직 DB에 반영되지 않은 조회수까지 더해 보여줍니다
+		article.ViewCount += int(s.views.count(context.Background(), article, viewer, client))
 	}
 
-	return response, nil
+	return newViewerArticleResponse(s.db, article, viewer.UserID)
+}
+
+// newViewerArticleResponse builds the response with the fields that depend on the viewer, so every
+// endpoint returning an article to a logged-in user shows their reactions and bookmark the same way
+func newViewerArticleResponse(db *gorm.DB, article *models.Article, userID uint) (*models.ArticleResponse, error) {
+	response := newArticleResponse(article)
+	if userID == 0 {
+		return &response, nil
+	}
+
+	mine, err := viewerReactions(db, userID, models.ReactionTargetArticle, []uint{article.ID})
+	if err != nil {
+		return nil, fmt.Errorf("반응 조회 실패: %w", err)
+	}
+	response.ViewerReactions = append(response.ViewerReactions, mine[article.ID]...)
+
+	if response.IsBookmarked, err = isBookmarked(db, userID, article.ID); err != nil {
+		return nil, fmt.Errorf("북마크 조회 실패: %w", err)
+	}
+	return &response, nil
 }
 
-func (s *ArticleService) GetArticles(lastID *uint, limit int) (*models.ArticleListResponse, error) {
+// GetArticles lists public articles. With a status other than published it lists the viewer's own
+// articles in that status instead (every author's for staff).
+func (s *ArticleService) GetArticles(params *ArticleListQuery, viewer policy.Actor) (*models.ArticleListResponse, error) {
+	limit := params.Limit
 	if limit <= 0 || limit > 50 {
 		limit = 20
 	}
 
-	query := s.db.Model(&models.Article{}).Preload("Author").Preload("Categories")
+	sortName := params.Sort
+	if sortName == "" {
+		sortName = ArticleSortNewest
+	}
+	order, ok := articleSorts[sortName]
+	if !ok {
+		return nil, errors.ErrInvalidInput("sort는 newest, oldest, views, comments 중 하나여야 합니다")
+	}
 
-	if lastID != nil && *lastID > 0 {
-		query = query.Where("id < ?", *lastID)
+	fields, err := parseArticleFields(params.Fields)
+	if err != nil {
+		return nil, err
 	}
 
-	query = query.Order("id DESC").Limit(limit + 1)
+	query := preloadArticleFields(s.db.Model(&models.Article{}).Select(articleListColumns), fields)
+
+	switch params.Status {
+	case "", models.ArticleStatusPublished:
+		query = query.Scopes(publicArticles(time.Now()))
+	default:
+		if viewer.UserID == 0 {
+			return nil, errors.ErrPermissionDenied()
+		}
+		query = query.Where("articles.status = ?", params.Status)
+		if !policy.CanViewUnpublishedArticles(viewer) {
+			query = query.Where("articles.author_id = ?", viewer.UserID)
+		}
+	}
+
+	if len(params.CategoryIDs) > 0 {
+		query = query.Where("articles.id IN (SELECT article_id FROM article_categories WHERE category_id IN ?)", params.CategoryIDs)
+	}
+	if params.AuthorID != nil {
+		query = query.Where("articles.author_id = ?", *params.AuthorID)
+	}
+	if params.From != nil {
+		query = query.Where(articleDateColumn+" >= ?", *params.From)
+	}
+	if params.To != nil {
+		// to는 그날 하루를 포함합니다
+		query = query.Where(articleDateColumn+" < ?", params.To.AddDate(0, 0, 1))
+	}
+
+	if params.Cursor != "" {
+		cursor, err := decodeArticleCursor(params.Cursor, sortName)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(order.after(), cursor.value(sortName), cursor.ID)
+	}
+
+	query = query.Order(order.orderBy()).Limit(limit + 1)
 
 	var articles []models.Article
 	if err := query.Find(&articles).Error; err != nil {
@@ -115,39 +347,37 @@ func (s *ArticleService) GetArticles(lastID *uint, limit int) (*models.ArticleLi
 		articles = articles[:limit]
 	}
 
-	responses := make([]models.ArticleResponse, len(articles))
-	for i, article := range articles {
-		categories := make([]models.CategoryInfo, len(article.Categories))
-		for j, cat := range article.Categories {
-			categories[j] = models.CategoryInfo{ID: cat.ID, Name: cat.Name}
-		}
-		responses[i] = models.ArticleResponse{
-			ID:         article.ID,
-			Title:      article.Title,
-			Content:    article.Content,
-			AuthorID:   article.AuthorID,
-			AuthorName: article.Author.Username,
-			ViewCount:  article.ViewCount,
-			Categories: categories,
-			CreatedAt:  article.CreatedAt,
-			UpdatedAt:  article.UpdatedAt,
-		}
+	ids := make([]uint, len(articles))
+	for i := range articles {
+		ids[i] = articles[i].ID
+	}
+	listViewer, err := loadArticleListViewer(s.db, viewer.UserID, fields, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, len(articles))
+	for i := range articles {
+		items[i] = newArticleListItem(&articles[i], fields, listViewer)
 	}
 
-	var nextCursor *uint
+	var nextCursor *string
 	if hasMore && len(articles) > 0 {
-		lastArticleID := articles[len(articles)-1].ID
-		nextCursor = &lastArticleID
+		cursor, err := encodeArticleCursor(&articles[len(articles)-1], sortName)
+		if err != nil {
+			return nil, fmt.Errorf("커서 생성 실패: %w", err)
+		}
+		nextCursor = &cursor
 	}
 
 	return &models.ArticleListResponse{
-		Articles:   responses,
+		Articles:   items,
 		NextCursor: nextCursor,
 		HasMore:    hasMore,
 	}, nil
 }
 
-func (s *ArticleService) UpdateArticle(id uint, req *UpdateArticleRequest, userID uint) (*models.Article, error) {
+func (s *ArticleService) UpdateArticle(id uint, req *UpdateArticleRequest, actor policy.Actor) (*models.ArticleResponse, error) {
 	var article models.Article
 	if err := s.db.First(&article, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -156,14 +386,36 @@ func (s *ArticleService) UpdateArticle(id uint, req *UpdateArticleRequest, userI
 		return nil, fmt.Errorf("게시글 조회 실패: %w", err)
 	}
 
-	if article.AuthorID != userID {
+	if !policy.CanEditArticle(actor, &article) {
 		return nil, errors.ErrPermissionDenied()
 	}
 
+	previous := article
 	article.Title = req.Title
 	article.Content = req.Content
+	if err := renderArticleContent(&article); err != nil {
+		return nil, err
+	}
+	if err := applyStatus(&article, req.Status, req.PublishedAt, time.Now()); err != nil {
+		return nil, err
+	}
 
-	if err := s.db.Save(&article).Error; err != nil {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if article.Title != previous.Title {
+			if err := s.slugs.assign(tx, &article); err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit(articleCounterColumns...).Save(&article).Error; err != nil {
+			return err
+		}
+		// 상태만 바뀐 경우에는 버전을 남기지 않습니다
+		if article.Title == previous.Title && article.Content == previous.Content {
+			return nil
+		}
+		return recordRevision(tx, &article, &previous, actor.UserID, nil)
+	})
+	if err != nil {
 		return nil, fmt.Errorf("게시글 수정 실패: %w", err)
 	}
 
@@ -184,11 +436,13 @@ func (s *ArticleService) UpdateArticle(id uint, req *UpdateArticleRequest, userI
 	if err := s.db.Preload("Author").Preload("Categories").First(&article, article.ID).Error; err != nil {
 		return nil, fmt.Errorf("게시글 로드 실패: %w", err)
 	}
+	// 비공개로 바뀐 글은 순위에서 빼고, 다시 공개된 글은 되돌립니다
+	syncViewRanking(context.Background(), &article)
 
-	return &article, nil
+	return newViewerArticleResponse(s.db, &article, actor.UserID)
 }
 
-func (s *ArticleService) DeleteArticle(id uint, userID uint) error {
+func (s *ArticleService) DeleteArticle(id uint, actor policy.Actor) error {
 	var article models.Article
 	if err := s.db.First(&article, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -197,8 +451,7 @@ func (s *ArticleService) DeleteArticle(id uint, userID uint) error {
 		return fmt.Errorf("게시글 조회 실패: %w", err)
 	}
 
-	// Check if user is the author
-	if article.AuthorID != userID {
+	if !policy.CanDeleteArticle(actor, &article) {
 		return errors.ErrPermissionDenied()
 	}
 
@@ -206,21 +459,29 @@ func (s *ArticleService) DeleteArticle(id uint, userID uint) error {
 		return fmt.Errorf("게시글 삭제 실패: %w", err)
 	}
 
+	removeFromRankings(context.Background(), article.ID)
+
 	return nil
 }
 
+// GetTopArticlesByViewCount returns the five most viewed public articles, ranked by the
+// view ranking sorted set in Redis (see ViewFlusher)
 func (s *ArticleService) GetTopArticlesByViewCount() ([]models.TopArticleInfo, error) {
-	var articles []models.Article
-	if err := s.db.Order("view_count DESC").Limit(5).Find(&articles).Error; err != nil {
+	const size = 5
+	ctx := context.Background()
+
+	ranked, err := publicRankedArticles(ctx, s.db, viewRankingKey, size)
+	if err != nil {
 		return nil, fmt.Errorf("인기 게시글 조회 실패: %w", err)
 	}
 
-	topArticles := make([]models.TopArticleInfo, len(articles))
-	for i, article := range articles {
+	topArticles := make([]models.TopArticleInfo, len(ranked))
+	for i, entry := range ranked {
 		topArticles[i] = models.TopArticleInfo{
-			ID:        article.ID,
-			Title:     article.Title,
-			ViewCount: article.ViewCount,
+			ID:        entry.article.ID,
+			Title:     entry.article.Title,
+			Slug:      entry.article.Slug,
+			ViewCount: int(entry.score),
 		}
 	}
 
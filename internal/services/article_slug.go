@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/utils"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// 이 횟수 안에 빈 번호를 찾지 못하면 임의의 접미사를 붙입니다
+const maxSlugSuffix = 50
+
+// articleSlugs assigns unique slugs to articles and keeps the history of replaced slugs
+type articleSlugs struct {
+	options utils.SlugOptions
+}
+
+func newArticleSlugs(cfg config.ArticleConfig) *articleSlugs {
+	fallback := utils.Slugify(cfg.SlugFallback, utils.SlugOptions{KeepHangul: cfg.SlugKeepHangul})
+	if fallback == "" {
+		fallback = "post"
+	}
+	return &articleSlugs{
+		options: utils.SlugOptions{KeepHangul: cfg.SlugKeepHangul, Fallback: fallback},
+	}
+}
+
+// assign sets the slug derived from the article's title. An article that already had a different
+// slug keeps the old one in its history so links to it can be redirected.
+func (g *articleSlugs) assign(tx *gorm.DB, article *models.Article) error {
+	slug, err := g.unique(tx, utils.Slugify(article.Title, g.options), article.ID)
+	if err != nil {
+		return err
+	}
+	if slug == article.Slug {
+		return nil
+	}
+
+	if article.Slug != "" && article.ID != 0 {
+		if err := tx.Create(&models.ArticleSlug{ArticleID: article.ID, Slug: article.Slug}).Error; err != nil {
+			return err
+		}
+	}
+	// 예전에 쓰던 슬러그로 돌아가면 기록에서 빼고 현재 슬러그로 씁니다
+	if article.ID != 0 {
+		if err := tx.Where("article_id = ? AND slug = ?", article.ID, slug).Delete(&models.ArticleSlug{}).Error; err != nil {
+			return err
+		}
+	}
+
+	article.Slug = slug
+	return nil
+}
+
+// unique returns base, or base-2, base-3, ... if base is already used by another article,
+// either as its current slug or in its history
+func (g *articleSlugs) unique(tx *gorm.DB, base string, articleID uint) (string, error) {
+	for i := 1; i <= maxSlugSuffix; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		taken, err := slugTaken(tx, candidate, articleID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+
+	suffix, err := utils.GenerateSecureToken(4)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%s", base, strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(suffix))), nil
+}
+
+func slugTaken(tx *gorm.DB, slug string, articleID uint) (bool, error) {
+	var count int64
+	// 삭제된 글의 슬러그도 고유 인덱스에 남아 있으므로 함께 확인합니다
+	if err := tx.Model(&models.Article{}).Unscoped().
+		Where("slug = ? AND id <> ?", slug, articleID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := tx.Model(&models.ArticleSlug{}).
+		Where("slug = ? AND article_id <> ?", slug, articleID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// BackfillArticleSlugs gives a slug to every article written before slugs existed
+func BackfillArticleSlugs() error {
+	db := database.GetDB()
+	slugs := newArticleSlugs(config.LoadConfig().Article)
+
+	var articles []models.Article
+	if err := db.Unscoped().Where("slug IS NULL OR slug = ''").Order("id").Find(&articles).Error; err != nil {
+		return fmt.Errorf("failed to load articles without slug: %w", err)
+	}
+
+	for i := range articles {
+		article := &articles[i]
+		article.Slug = ""
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := slugs.assign(tx, article); err != nil {
+				return err
+			}
+			return tx.Unscoped().Model(article).UpdateColumn("slug", article.Slug).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to assign slug to article %d: %w", article.ID, err)
+		}
+	}
+
+	if len(articles) > 0 {
+		log.Printf("Assigned slugs to %d article(s)", len(articles))
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"portfolio-server/internal/config"
 	"portfolio-server/internal/database"
 	"portfolio-server/internal/errors"
 	"portfolio-server/internal/middleware"
@@ -14,32 +15,52 @@ import (
 )
 
 type AuthService struct {
-	db *gorm.DB
+	db          *gorm.DB
+	tokens      *TokenService
+	twoFactor   *TwoFactorService
+	signupCodes *verificationCodes
+	loginGuard  *loginGuard
 }
 
 func NewAuthService() *AuthService {
+	cfg := config.LoadConfig()
+	db := database.GetDB()
 	return &AuthService{
-		db: database.GetDB(),
+		db:          db,
+		tokens:      NewTokenService(),
+		twoFactor:   NewTwoFactorService(),
+		signupCodes: newVerificationCodes("verification", cfg.Verification),
+		loginGuard:  newLoginGuard(db, cfg.Login),
 	}
 }
 
 type RegisterRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Username string `json:"username" binding:"required,min=3,max=30"`
-	Password string `json:"password" binding:"required,min=6"`
+	Email             string `json:"email" binding:"required,email"`
+	Username          string `json:"username" binding:"required,min=3,max=30"`
+	Password          string `json:"password" binding:"required,min=6"`
+	VerificationToken string `json:"verification_token" binding:"required"`
+	DeviceName        string `json:"device_name" binding:"omitempty,max=100"`
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
+	// 세션 목록에 표시할 기기 이름 (선택)
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"`
 }
 
+// AuthResponse is returned after a successful login. When TwoFactorRequired is set no session
+// was started yet; the challenge token must be sent to /auth/login/2fa with a second factor.
 type AuthResponse struct {
-	Token string           `json:"token"`
-	User  models.User      `json:"user"`
+	Token             string       `json:"token,omitempty"`
+	RefreshToken      string       `json:"refresh_token,omitempty"`
+	ExpiresIn         int          `json:"expires_in"`
+	User              *models.User `json:"user,omitempty"`
+	TwoFactorRequired bool         `json:"two_factor_required,omitempty"`
+	ChallengeToken    string       `json:"challenge_token,omitempty"`
 }
 
-func (s *AuthService) Register(req *RegisterRequest) (*AuthResponse, error) {
+func (s *AuthService) Register(req *RegisterRequest, client middleware.ClientInfo) (*AuthResponse, error) {
 	var existingUser models.User
 	if err := s.db.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
 		return nil, errors.ErrEmailAlreadyExists()
@@ -49,55 +70,56 @@ func (s *AuthService) Register(req *RegisterRequest) (*AuthResponse, error) {
 		return nil, errors.ErrUsernameAlreadyExists()
 	}
 
+	if err := s.consumeVerifiedEmailToken(req.Email, req.VerificationToken); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, errors.NewAppError(500, "비밀번호 암호화에 실패했습니다", err.Error())
 	}
 
+	verifiedAt := time.Now()
 	user := models.User{
-		Email:    req.Email,
-		Username: req.Username,
-		Password: hashedPassword,
+		Email:           req.Email,
+		Username:        req.Username,
+		Password:        hashedPassword,
+		EmailVerifiedAt: &verifiedAt,
 	}
 
 	if err := s.db.Create(&user).Error; err != nil {
 		return nil, errors.NewAppError(500, "사용자 생성에 실패했습니다", err.Error())
 	}
 
-	token, err := middleware.GenerateToken(user.ID, user.Email, user.Username)
-	if err != nil {
-		return nil, errors.NewAppError(500, "Failed to generate token", err.Error())
-	}
-
-	return &AuthResponse{
-		Token: token,
-		User:  user,
-	}, nil
+	return s.tokens.IssueTokens(&user, client)
 }
 
-func (s *AuthService) Login(req *LoginRequest) (*AuthResponse, error) {
+// Login checks the password. Repeated failures for an email are delayed and then locked out,
+// and every attempt is recorded in login_attempts.
+func (s *AuthService) Login(req *LoginRequest, client middleware.ClientInfo) (*AuthResponse, error) {
+	ctx := context.Background()
+
+	if err := s.loginGuard.check(ctx, req.Email, client.IP); err != nil {
+		s.loginGuard.blocked(req.Email, client, err)
+		return nil, err
+	}
+
 	var user models.User
 	if err := s.db.Where("email = ?", req.Email).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
+			s.loginGuard.fail(ctx, nil, req.Email, client, models.LoginResultInvalidCredentials)
 			return nil, errors.ErrInvalidCredentials()
 		}
 		return nil, errors.NewAppError(500, "데이터베이스 오류가 발생했습니다", err.Error())
 	}
 
 	if err := utils.CheckPassword(user.Password, req.Password); err != nil {
+		s.loginGuard.fail(ctx, &user, req.Email, client, models.LoginResultInvalidCredentials)
 		return nil, errors.ErrInvalidCredentials()
 	}
 
-	// Generate JWT token
-	token, err := middleware.GenerateToken(user.ID, user.Email, user.Username)
-	if err != nil {
-		return nil, errors.NewAppError(500, "토큰 생성에 실패했습니다", err.Error())
-	}
-
-	return &AuthResponse{
-		Token: token,
-		User:  user,
-	}, nil
+	// 성공 기록은 StartLogin(2단계 인증을 쓰면 CompleteLogin)이 남깁니다
+	return s.twoFactor.StartLogin(&user, client)
 }
 
 func (s *AuthService) GetProfile(userID uint) (*models.User, error) {
@@ -124,53 +146,59 @@ type VerifyCodeRequest struct {
 }
 
 // SendVerificationCode generates and sends a verification code to the user's email
-func (s *AuthService) SendVerificationCode(email string) error {
+func (s *AuthService) SendVerificationCode(email, clientIP string) error {
 	// 이메일 중복 체크
 	var existingUser models.User
 	if err := s.db.Where("email = ?", email).First(&existingUser).Error; err == nil {
 		return errors.ErrEmailAlreadyExists()
 	}
 
-	// 6자리 인증 코드 생성
-	code, err := utils.GenerateVerificationCode()
-	if err != nil {
-		return errors.NewAppError(500, "인증 코드 생성에 실패했습니다", err.Error())
-	}
+	return s.signupCodes.Send(email, email, clientIP, func(code string) error {
+		return utils.SendVerificationEmail(email, code)
+	})
+}
 
-	// Redis에 인증 코드 저장 (10분 TTL)
-	ctx := context.Background()
-	redisKey := fmt.Sprintf("verification:%s", email)
-	err = database.GetRedis().Set(ctx, redisKey, code, 10*time.Minute).Err()
-	if err != nil {
-		return errors.NewAppError(500, "인증 코드 저장에 실패했습니다", err.Error())
-	}
+// VerifyCodeResponse carries the single-use token proving the email was verified
+type VerifyCodeResponse struct {
+	Message           string `json:"message"`
+	VerificationToken string `json:"verification_token"`
+	ExpiresIn         int    `json:"expires_in"`
+}
 
-	// 이메일 전송
-	if err := utils.SendVerificationEmail(email, code); err != nil {
-		return errors.NewAppError(500, "이메일 전송에 실패했습니다", err.Error())
-	}
+// 인증 완료 토큰 유효 시간. 이 시간 안에 회원가입을 마쳐야 합니다
+const verifiedEmailTokenTTL = 30 * time.Minute
 
-	return nil
+func verifiedEmailKey(token string) string {
+	return fmt.Sprintf("verified_email:%s", utils.HashToken(token))
 }
 
-// VerifyCode verifies the verification code
-func (s *AuthService) VerifyCode(email, code string) error {
-	ctx := context.Background()
-	redisKey := fmt.Sprintf("verification:%s", email)
+// VerifyCode verifies the verification code and issues a verified email token for Register
+func (s *AuthService) VerifyCode(email, code, clientIP string) (*VerifyCodeResponse, error) {
+	if err := s.signupCodes.Verify(email, code, clientIP); err != nil {
+		return nil, err
+	}
 
-	// Redis에서 저장된 코드 조회
-	savedCode, err := database.GetRedis().Get(ctx, redisKey).Result()
+	// 회원가입 시 한 번만 사용할 수 있는 인증 완료 토큰 발급
+	token, err := utils.GenerateSecureToken(32)
 	if err != nil {
-		return errors.NewAppError(400, "인증 코드가 유효하지 않습니다", "코드를 찾을 수 없거나 만료되었습니다")
+		return nil, errors.NewAppError(500, "인증 토큰 생성에 실패했습니다", err.Error())
 	}
-
-	// 코드 비교
-	if savedCode != code {
-		return errors.NewAppError(400, "인증 코드가 유효하지 않습니다", "코드가 일치하지 않습니다")
+	if err := database.GetRedis().Set(context.Background(), verifiedEmailKey(token), email, verifiedEmailTokenTTL).Err(); err != nil {
+		return nil, errors.NewAppError(500, "인증 토큰 저장에 실패했습니다", err.Error())
 	}
 
-	// 인증 성공 시 Redis에서 삭제
-	database.GetRedis().Del(ctx, redisKey)
+	return &VerifyCodeResponse{
+		Message:           "이메일 인증이 완료되었습니다",
+		VerificationToken: token,
+		ExpiresIn:         int(verifiedEmailTokenTTL.Seconds()),
+	}, nil
+}
 
+// consumeVerifiedEmailToken checks that the token was issued for the email and invalidates it
+func (s *AuthService) consumeVerifiedEmailToken(email, token string) error {
+	verifiedEmail, err := database.GetRedis().GetDel(context.Background(), verifiedEmailKey(token)).Result()
+	if err != nil || verifiedEmail != email {
+		return errors.ErrEmailNotVerified()
+	}
 	return nil
 }
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookmarkService struct {
+	db *gorm.DB
+}
+
+func NewBookmarkService() *BookmarkService {
+	return &BookmarkService{
+		db: database.GetDB(),
+	}
+}
+
+// BookmarkListQuery is the query string of GET /me/bookmarks
+type BookmarkListQuery struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+	Fields string `form:"fields"`
+}
+
+// bookmarkCursor is the position of the last bookmark of a page, sent to clients as base64 encoded JSON
+type bookmarkCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"id"`
+}
+
+func encodeBookmarkCursor(bookmark *models.Bookmark) (string, error) {
+	data, err := json.Marshal(bookmarkCursor{CreatedAt: bookmark.CreatedAt, ID: bookmark.ID})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeBookmarkCursor(value string) (*bookmarkCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.ErrInvalidCursor()
+	}
+
+	var cursor bookmarkCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 || cursor.CreatedAt.IsZero() {
+		return nil, errors.ErrInvalidCursor()
+	}
+	return &cursor, nil
+}
+
+// isBookmarked reports whether the article is on the user's reading list
+func isBookmarked(db *gorm.DB, userID, articleID uint) (bool, error) {
+	if userID == 0 {
+		return false, nil
+	}
+	var count int64
+	err := db.Model(&models.Bookmark{}).Where("user_id = ? AND article_id = ?", userID, articleID).Count(&count).Error
+	return count > 0, err
+}
+
+// AddBookmark puts an article the actor can see on their reading list. Bookmarking an article twice
+// keeps the first bookmark; created reports whether a new one was made.
+func (s *BookmarkService) AddBookmark(articleID uint, actor policy.Actor) (*models.BookmarkResponse, bool, error) {
+	var article models.Article
+	if err := s.db.First(&article, articleID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, false, errors.ErrArticleNotFound()
+		}
+		return nil, false, fmt.Errorf("게시글 조회 실패: %w", err)
+	}
+	if !policy.CanViewArticle(actor, &article, time.Now()) {
+		return nil, false, errors.ErrArticleNotFound()
+	}
+
+	bookmark := models.Bookmark{UserID: actor.UserID, ArticleID: articleID}
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&bookmark)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("북마크 저장 실패: %w", result.Error)
+	}
+
+	created := result.RowsAffected > 0
+	if !created {
+		if err := s.db.Where("user_id = ? AND article_id = ?", actor.UserID, articleID).First(&bookmark).Error; err != nil {
+			return nil, false, fmt.Errorf("북마크 조회 실패: %w", err)
+		}
+	}
+
+	return &models.BookmarkResponse{
+		ArticleID:    bookmark.ArticleID,
+		BookmarkedAt: bookmark.CreatedAt,
+	}, created, nil
+}
+
+// RemoveBookmark takes an article off the actor's reading list. Removing an article that is not on the
+// list, or that was deleted since, is not an error.
+func (s *BookmarkService) RemoveBookmark(articleID uint, actor policy.Actor) error {
+	if err := s.db.Where("user_id = ? AND article_id = ?", actor.UserID, articleID).Delete(&models.Bookmark{}).Error; err != nil {
+		return fmt.Errorf("북마크 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+// GetBookmarks lists the actor's reading list, most recently bookmarked first. Bookmarks of articles that
+// were deleted or are no longer visible to the actor are left out rather than reported as errors.
+func (s *BookmarkService) GetBookmarks(params *BookmarkListQuery, actor policy.Actor) (*models.ArticleListResponse, error) {
+	limit := params.Limit
+	if limit <= 0 || limit > 50 {
+		limit = 20
+	}
+
+	fields, err := parseArticleFields(params.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.db.Model(&models.Bookmark{}).Select("bookmarks.*").
+		Joins("JOIN articles ON articles.id = bookmarks.article_id AND articles.deleted_at IS NULL").
+		Where("bookmarks.user_id = ?", actor.UserID)
+	// 북마크한 뒤 비공개로 바뀐 다른 사람의 글은 숨깁니다
+	if !policy.CanViewUnpublishedArticles(actor) {
+		query = query.Where("("+publicArticleCondition+" OR articles.author_id = ?)", time.Now(), actor.UserID)
+	}
+
+	if params.Cursor != "" {
+		cursor, err := decodeBookmarkCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(bookmarks.created_at, bookmarks.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	var bookmarks []models.Bookmark
+	if err := query.Order("bookmarks.created_at DESC, bookmarks.id DESC").Limit(limit + 1).Find(&bookmarks).Error; err != nil {
+		return nil, fmt.Errorf("북마크 목록 조회 실패: %w", err)
+	}
+
+	hasMore := len(bookmarks) > limit
+	if hasMore {
+		bookmarks = bookmarks[:limit]
+	}
+
+	ids := make([]uint, len(bookmarks))
+	for i := range bookmarks {
+		ids[i] = bookmarks[i].ArticleID
+	}
+
+	var articles []models.Article
+	if len(ids) > 0 {
+		if err := preloadArticleFields(s.db.Select(articleListColumns), fields).
+			Where("id IN ?", ids).Find(&articles).Error; err != nil {
+			return nil, fmt.Errorf("게시글 목록 조회 실패: %w", err)
+		}
+	}
+	found := make(map[uint]*models.Article, len(articles))
+	for i := range articles {
+		found[articles[i].ID] = &articles[i]
+	}
+
+	viewer, err := loadArticleListViewer(s.db, actor.UserID, fields, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, 0, len(bookmarks))
+	for i := range bookmarks {
+		article, ok := found[bookmarks[i].ArticleID]
+		if !ok {
+			// 두 쿼리 사이에 삭제된 글
+			continue
+		}
+		item := newArticleListItem(article, fields, viewer)
+		item["bookmarked_at"] = bookmarks[i].CreatedAt
+		items = append(items, item)
+	}
+
+	var nextCursor *string
+	if hasMore && len(bookmarks) > 0 {
+		cursor, err := encodeBookmarkCursor(&bookmarks[len(bookmarks)-1])
+		if err != nil {
+			return nil, fmt.Errorf("커서 생성 실패: %w", err)
+		}
+		nextCursor = &cursor
+	}
+
+	return &models.ArticleListResponse{
+		Articles:   items,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
@@ -1,10 +1,13 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"portfolio-server/internal/database"
 	"portfolio-server/internal/errors"
 	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -27,10 +30,24 @@ type UpdateCommentRequest struct {
 	Content string `json:"content" binding:"required,min=1"`
 }
 
+func newCommentResponse(comment *models.Comment) models.CommentResponse {
+	return models.CommentResponse{
+		ID:              comment.ID,
+		Content:         comment.Content,
+		AuthorID:        comment.AuthorID,
+		AuthorName:      authorName(comment.Author),
+		ArticleID:       comment.ArticleID,
+		Reactions:       reactionCounts(comment.ReactionCounts),
+		ViewerReactions: []string{},
+		CreatedAt:       comment.CreatedAt,
+		UpdatedAt:       comment.UpdatedAt,
+	}
+}
+
 func (s *CommentService) CreateComment(articleID uint, req *CreateCommentRequest, authorID uint) (*models.CommentResponse, error) {
-	// Check if article exists
+	// Check if article exists and is public
 	var article models.Article
-	if err := s.db.First(&article, articleID).Error; err != nil {
+	if err := s.db.Scopes(publicArticles(time.Now())).First(&article, articleID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrArticleNotFound()
 		}
@@ -43,30 +60,32 @@ func (s *CommentService) CreateComment(articleID uint, req *CreateCommentRequest
 		ArticleID: articleID,
 	}
 
-	if err := s.db.Create(&comment).Error; err != nil {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&comment).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Article{}).Where("id = ?", articleID).
+			UpdateColumn("comment_count", gorm.Expr("comment_count + 1")).Error
+	})
+	if err != nil {
 		return nil, fmt.Errorf("댓글 생성 실패: %w", err)
 	}
+	recordDailyActivity(context.Background(), dailyCommentsKeyPrefix, articleID, comment.CreatedAt)
 
 	// Load author
 	if err := s.db.Preload("Author").First(&comment, comment.ID).Error; err != nil {
 		return nil, fmt.Errorf("댓글 로드 실패: %w", err)
 	}
 
-	return &models.CommentResponse{
-		ID:         comment.ID,
-		Content:    comment.Content,
-		AuthorID:   comment.AuthorID,
-		AuthorName: comment.Author.Username,
-		ArticleID:  comment.ArticleID,
-		CreatedAt:  comment.CreatedAt,
-		UpdatedAt:  comment.UpdatedAt,
-	}, nil
+	response := newCommentResponse(&comment)
+	return &response, nil
 }
 
-func (s *CommentService) GetCommentsByArticleID(articleID uint, lastID *uint, limit int) (*models.CommentListResponse, error) {
-	// Check if article exists
+// GetCommentsByArticleID lists the comments of a public article. viewer_reactions is filled for a logged in viewer.
+func (s *CommentService) GetCommentsByArticleID(articleID uint, lastID *uint, limit int, viewer policy.Actor) (*models.CommentListResponse, error) {
+	// Check if article exists and is public
 	var article models.Article
-	if err := s.db.First(&article, articleID).Error; err != nil {
+	if err := s.db.Scopes(publicArticles(time.Now())).First(&article, articleID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrArticleNotFound()
 		}
@@ -97,17 +116,19 @@ func (s *CommentService) GetCommentsByArticleID(articleID uint, lastID *uint, li
 		comments = comments[:limit]
 	}
 
+	ids := make([]uint, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].ID
+	}
+	mine, err := viewerReactions(s.db, viewer.UserID, models.ReactionTargetComment, ids)
+	if err != nil {
+		return nil, fmt.Errorf("반응 조회 실패: %w", err)
+	}
+
 	responses := make([]models.CommentResponse, len(comments))
-	for i, comment := range comments {
-		responses[i] = models.CommentResponse{
-			ID:         comment.ID,
-			Content:    comment.Content,
-			AuthorID:   comment.AuthorID,
-			AuthorName: comment.Author.Username,
-			ArticleID:  comment.ArticleID,
-			CreatedAt:  comment.CreatedAt,
-			UpdatedAt:  comment.UpdatedAt,
-		}
+	for i := range comments {
+		responses[i] = newCommentResponse(&comments[i])
+		responses[i].ViewerReactions = append(responses[i].ViewerReactions, mine[comments[i].ID]...)
 	}
 
 	var nextCursor *uint
@@ -123,7 +144,7 @@ func (s *CommentService) GetCommentsByArticleID(articleID uint, lastID *uint, li
 	}, nil
 }
 
-func (s *CommentService) UpdateComment(commentID uint, req *UpdateCommentRequest, userID uint) (*models.CommentResponse, error) {
+func (s *CommentService) UpdateComment(commentID uint, req *UpdateCommentRequest, actor policy.Actor) (*models.CommentResponse, error) {
 	var comment models.Comment
 	if err := s.db.First(&comment, commentID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -132,13 +153,14 @@ func (s *CommentService) UpdateComment(commentID uint, req *UpdateCommentRequest
 		return nil, fmt.Errorf("댓글 조회 실패: %w", err)
 	}
 
-	if comment.AuthorID != userID {
+	if !policy.CanEditComment(actor, &comment) {
 		return nil, errors.ErrPermissionDenied()
 	}
 
 	comment.Content = req.Content
 
-	if err := s.db.Save(&comment).Error; err != nil {
+	// 반응 수는 반응을 남길 때 따로 갱신하므로 덮어쓰지 않습니다
+	if err := s.db.Omit("reaction_counts").Save(&comment).Error; err != nil {
 		return nil, fmt.Errorf("댓글 수정 실패: %w", err)
 	}
 
@@ -147,18 +169,11 @@ func (s *CommentService) UpdateComment(commentID uint, req *UpdateCommentRequest
 		return nil, fmt.Errorf("댓글 로드 실패: %w", err)
 	}
 
-	return &models.CommentResponse{
-		ID:         comment.ID,
-		Content:    comment.Content,
-		AuthorID:   comment.AuthorID,
-		AuthorName: comment.Author.Username,
-		ArticleID:  comment.ArticleID,
-		CreatedAt:  comment.CreatedAt,
-		UpdatedAt:  comment.UpdatedAt,
-	}, nil
+	response := newCommentResponse(&comment)
+	return &response, nil
 }
 
-func (s *CommentService) DeleteComment(commentID uint, userID uint) error {
+func (s *CommentService) DeleteComment(commentID uint, actor policy.Actor) error {
 	var comment models.Comment
 	if err := s.db.First(&comment, commentID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -167,11 +182,22 @@ func (s *CommentService) DeleteComment(commentID uint, userID uint) error {
 		return fmt.Errorf("댓글 조회 실패: %w", err)
 	}
 
-	if comment.AuthorID != userID {
+	if !policy.CanModerateComment(actor, &comment) {
 		return errors.ErrPermissionDenied()
 	}
 
-	if err := s.db.Delete(&comment).Error; err != nil {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&comment).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("target_type = ? AND target_id = ?", models.ReactionTargetComment, comment.ID).
+			Delete(&models.Reaction{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Article{}).Unscoped().Where("id = ? AND comment_count > 0", comment.ArticleID).
+			UpdateColumn("comment_count", gorm.Expr("comment_count - 1")).Error
+	})
+	if err != nil {
 		return fmt.Errorf("댓글 삭제 실패: %w", err)
 	}
 
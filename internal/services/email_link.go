@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// emailLinkKind describes a single-use link sent by email, like a password reset or login link
+type emailLinkKind struct {
+	// Redis 키 접두사. 토큰은 "<prefix>:<해시>", 사용자의 현재 링크는 "<prefix>:user:<ID>"에 저장합니다
+	prefix string
+	// 로그에 쓰는 이름과 오류 메시지에 쓰는 이름
+	name  string
+	label string
+	// 프론트엔드에서 링크를 받는 경로
+	path string
+	// 링크 유효 시간 (메일 본문에 안내된 시간과 같아야 합니다)
+	ttl time.Duration
+	// 주소별 일일 발송 한도를 적용할지
+	dailyLimit bool
+	send       func(email, link string) error
+}
+
+var passwordResetLink = emailLinkKind{
+	prefix: "password_reset",
+	name:   "password reset",
+	label:  "재설정 토큰",
+	path:   "/reset-password",
+	ttl:    30 * time.Minute,
+	send:   utils.SendPasswordResetEmail,
+}
+
+var magicLink = emailLinkKind{
+	prefix:     "magic_link",
+	name:       "magic link",
+	label:      "로그인 링크",
+	path:       "/magic-link",
+	ttl:        15 * time.Minute,
+	dailyLimit: true,
+	send:       utils.SendMagicLinkEmail,
+}
+
+// emailLinks issues and consumes links of one kind
+type emailLinks struct {
+	db           *gorm.DB
+	frontendURL  string
+	verification config.VerificationConfig
+	kind         emailLinkKind
+}
+
+func newEmailLinks(kind emailLinkKind) *emailLinks {
+	cfg := config.LoadConfig()
+	return &emailLinks{
+		db:           database.GetDB(),
+		frontendURL:  cfg.Server.FrontendURL,
+		verification: cfg.Verification,
+		kind:         kind,
+	}
+}
+
+func (l *emailLinks) tokenKey(tokenHash string) string {
+	return fmt.Sprintf("%s:%s", l.kind.prefix, tokenHash)
+}
+
+func (l *emailLinks) userKey(userID uint) string {
+	return fmt.Sprintf("%s:user:%d", l.kind.prefix, userID)
+}
+
+// send emails a new link if an account exists for the email. The result is the same whether or not
+// the account exists, so the endpoint cannot be used to find users; only the IP limit is reported.
+func (l *emailLinks) send(email, clientIP string) error {
+	ctx := context.Background()
+
+	ipLimit, err := hitRateLimit(ctx, fmt.Sprintf("%s:ip:send:%s", l.kind.prefix, clientIP), time.Hour)
+	if err != nil {
+		return errors.NewAppError(500, "요청 제한 확인에 실패했습니다", err.Error())
+	}
+	if ipLimit.Count > int64(l.verification.IPSendLimitPerHour) {
+		return errors.ErrTooManyRequests(ipLimit.RetryAfter)
+	}
+
+	// 같은 주소로 메일이 반복 발송되지 않도록 쿨다운(과 일일 한도)을 넘으면 조용히 무시합니다
+	ok, _, err := acquireCooldown(ctx, fmt.Sprintf("%s:cooldown:%s", l.kind.prefix, email), time.Duration(l.verification.ResendCooldownSeconds)*time.Second)
+	if err != nil {
+		return errors.NewAppError(500, "요청 제한 확인에 실패했습니다", err.Error())
+	}
+	if !ok {
+		return nil
+	}
+	if l.kind.dailyLimit {
+		daily, err := hitRateLimit(ctx, fmt.Sprintf("%s:daily:%s", l.kind.prefix, email), 24*time.Hour)
+		if err != nil {
+			return errors.NewAppError(500, "요청 제한 확인에 실패했습니다", err.Error())
+		}
+		if daily.Count > int64(l.verification.DailySendLimit) {
+			return nil
+		}
+	}
+
+	var user models.User
+	if err := l.db.Where("email = ?", email).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return errors.NewAppError(500, "데이터베이스 오류가 발생했습니다", err.Error())
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return errors.NewAppError(500, l.kind.label+" 생성에 실패했습니다", err.Error())
+	}
+
+	// 새 링크를 발급하면 이전 링크는 무효화됩니다
+	rdb := database.GetRedis()
+	if previous, err := rdb.Get(ctx, l.userKey(user.ID)).Result(); err == nil {
+		rdb.Del(ctx, l.tokenKey(previous))
+	}
+
+	tokenHash := utils.HashToken(token)
+	pipe := rdb.TxPipeline()
+	pipe.Set(ctx, l.tokenKey(tokenHash), user.ID, l.kind.ttl)
+	pipe.Set(ctx, l.userKey(user.ID), tokenHash, l.kind.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.NewAppError(500, l.kind.label+" 저장에 실패했습니다", err.Error())
+	}
+
+	link := fmt.Sprintf("%s%s?token=%s", strings.TrimRight(l.frontendURL, "/"), l.kind.path, url.QueryEscape(token))
+
+	// 메일 발송 시간으로 계정 존재 여부가 드러나지 않도록 비동기로 전송합니다
+	go func() {
+		if err := l.kind.send(user.Email, link); err != nil {
+			log.Printf("Failed to send %s email: %v", l.kind.name, err)
+		}
+	}()
+
+	return nil
+}
+
+// consume uses up a link token and returns the user it was sent to. ok is false when the token
+// is unknown, expired or already used.
+func (l *emailLinks) consume(ctx context.Context, token string) (uint, bool) {
+	rdb := database.GetRedis()
+
+	value, err := rdb.GetDel(ctx, l.tokenKey(utils.HashToken(token))).Result()
+	if err != nil {
+		return 0, false
+	}
+
+	userID, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	rdb.Del(ctx, l.userKey(uint(userID)))
+
+	return uint(userID), true
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type LoginAttemptService struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptService() *LoginAttemptService {
+	return &LoginAttemptService{
+		db: database.GetDB(),
+	}
+}
+
+// LoginAttemptFilter narrows down the login attempt list. Empty fields are ignored.
+type LoginAttemptFilter struct {
+	Email   string `form:"email"`
+	IP      string `form:"ip"`
+	UserID  *uint  `form:"user_id"`
+	Success *bool  `form:"success"`
+	LastID  *uint  `form:"last_id"`
+	Limit   int    `form:"limit"`
+}
+
+// ListLoginAttempts returns login attempts, newest first, with the same cursor as the article list
+func (s *LoginAttemptService) ListLoginAttempts(filter *LoginAttemptFilter) (*models.LoginAttemptListResponse, error) {
+	limit := filter.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	query := s.db.Model(&models.LoginAttempt{})
+	if filter.Email != "" {
+		query = query.Where("email = ?", normalizeLoginEmail(filter.Email))
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", filter.IP)
+	}
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Success != nil {
+		query = query.Where("success = ?", *filter.Success)
+	}
+	if filter.LastID != nil && *filter.LastID > 0 {
+		query = query.Where("id < ?", *filter.LastID)
+	}
+
+	var attempts []models.LoginAttempt
+	if err := query.Order("id DESC").Limit(limit + 1).Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("로그인 기록 조회 실패: %w", err)
+	}
+
+	hasMore := len(attempts) > limit
+	if hasMore {
+		attempts = attempts[:limit]
+	}
+
+	response := &models.LoginAttemptListResponse{
+		Attempts: attempts,
+		HasMore:  hasMore,
+	}
+	if hasMore && len(attempts) > 0 {
+		response.NextCursor = &attempts[len(attempts)-1].ID
+	}
+
+	return response, nil
+}
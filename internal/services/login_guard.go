@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// loginGuard throttles password logins and records every login attempt, whatever the method.
+//
+// 실패 횟수는 이메일과 IP 기준으로 Redis에 저장됩니다.
+//
+//	login:fail:<email>    이메일별 실패 횟수 (FailureWindowMinutes 동안 유지)
+//	login:delay:<email>   다음 시도까지 기다려야 하는 동안 존재
+//	login:lock:<email>    잠금 기간 동안 존재
+//	login:fail:ip:<ip>    IP별 1시간 실패 횟수
+//
+// 존재하지 않는 이메일도 똑같이 제한하므로 응답으로 계정 존재 여부를 알 수 없습니다.
+type loginGuard struct {
+	db  *gorm.DB
+	cfg config.LoginSecurityConfig
+}
+
+func newLoginGuard(db *gorm.DB, cfg config.LoginSecurityConfig) *loginGuard {
+	return &loginGuard{db: db, cfg: cfg}
+}
+
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// check returns an error when the email is locked or throttled, or the IP failed too often
+func (g *loginGuard) check(ctx context.Context, email, ip string) error {
+	rdb := database.GetRedis()
+	email = normalizeLoginEmail(email)
+
+	if ttl, err := rdb.TTL(ctx, fmt.Sprintf("login:lock:%s", email)).Result(); err == nil && ttl > 0 {
+		return errors.ErrAccountLocked(int(ttl.Round(time.Second).Seconds()))
+	}
+	if ttl, err := rdb.TTL(ctx, fmt.Sprintf("login:delay:%s", email)).Result(); err == nil && ttl > 0 {
+		return errors.ErrLoginThrottled(int(ttl.Round(time.Second).Seconds()))
+	}
+
+	return g.checkIP(ctx, ip)
+}
+
+// checkIP returns an error when the IP failed too often, with a password or a second factor
+func (g *loginGuard) checkIP(ctx context.Context, ip string) error {
+	ipKey := fmt.Sprintf("login:fail:ip:%s", ip)
+	if count, err := database.GetRedis().Get(ctx, ipKey).Int64(); err == nil && count >= int64(g.cfg.IPFailureLimit) {
+		return errors.ErrTooManyRequests(retryAfterSeconds(ctx, ipKey, time.Hour))
+	}
+	return nil
+}
+
+// fail counts a wrong password, unknown email or wrong second factor (see result) and applies the
+// progressive delay or lockout. user is nil when no account exists for the email.
+func (g *loginGuard) fail(ctx context.Context, user *models.User, email string, client middleware.ClientInfo, result string) {
+	rdb := database.GetRedis()
+	email = normalizeLoginEmail(email)
+	window := time.Duration(g.cfg.FailureWindowMinutes) * time.Minute
+
+	g.record(user, email, client, result)
+
+	if _, err := hitRateLimit(ctx, fmt.Sprintf("login:fail:ip:%s", client.IP), time.Hour); err != nil {
+		log.Printf("Failed to count login failure: %v", err)
+	}
+
+	failures, err := hitRateLimit(ctx, fmt.Sprintf("login:fail:%s", email), window)
+	if err != nil {
+		log.Printf("Failed to count login failure: %v", err)
+		return
+	}
+
+	switch {
+	case failures.Count >= int64(g.cfg.LockoutThreshold):
+		rdb.Set(ctx, fmt.Sprintf("login:lock:%s", email), 1, time.Duration(g.cfg.LockoutMinutes)*time.Minute)
+	case failures.Count > int64(g.cfg.FreeAttempts):
+		rdb.Set(ctx, fmt.Sprintf("login:delay:%s", email), 1, loginDelay(failures.Count-int64(g.cfg.FreeAttempts), g.cfg.MaxDelaySeconds))
+	}
+
+	if user != nil && failures.Count == int64(g.cfg.AlertThreshold) {
+		go func(to string, count int64, ip string) {
+			if err := utils.SendLoginFailureAlertEmail(to, int(count), ip); err != nil {
+				log.Printf("Failed to send login failure alert: %v", err)
+			}
+		}(user.Email, failures.Count, client.IP)
+	}
+}
+
+// loginDelay doubles the wait for every failure past the free attempts: 1s, 2s, 4s, ...
+func loginDelay(extraFailures int64, maxSeconds int) time.Duration {
+	seconds := int64(maxSeconds)
+	if extraFailures-1 < 30 {
+		if delay := int64(1) << (extraFailures - 1); delay < seconds {
+			seconds = delay
+		}
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// rejected records a failed magic link or social login. Nothing was guessed for a particular email,
+// so only the IP failure counter is increased. user is nil and email empty when they are not known.
+func (g *loginGuard) rejected(ctx context.Context, user *models.User, email string, client middleware.ClientInfo, result string) {
+	g.record(user, normalizeLoginEmail(email), client, result)
+
+	if _, err := hitRateLimit(ctx, fmt.Sprintf("login:fail:ip:%s", client.IP), time.Hour); err != nil {
+		log.Printf("Failed to count login failure: %v", err)
+	}
+}
+
+// blocked records an attempt that was rejected by check before the password was looked at
+func (g *loginGuard) blocked(email string, client middleware.ClientInfo, err error) {
+	result := models.LoginResultRateLimited
+	if appErr, ok := err.(*errors.AppError); ok && appErr.Reason == "account_locked" {
+		result = models.LoginResultLocked
+	}
+	g.record(nil, normalizeLoginEmail(email), client, result)
+}
+
+// succeed clears the failure counters and warns the owner when the login comes from
+// an IP and user agent that never logged in to the account before.
+// It is called from TwoFactorService for every login method: right away for accounts without
+// two-factor authentication, and only once the second factor passed for the others.
+func (g *loginGuard) succeed(ctx context.Context, user *models.User, client middleware.ClientInfo) {
+	email := normalizeLoginEmail(user.Email)
+	database.GetRedis().Del(ctx, fmt.Sprintf("login:fail:%s", email), fmt.Sprintf("login:delay:%s", email))
+
+	var previous, known int64
+	g.db.Model(&models.LoginAttempt{}).Where("user_id = ? AND success = ?", user.ID, true).Count(&previous)
+	g.db.Model(&models.LoginAttempt{}).
+		Where("user_id = ? AND success = ? AND ip = ? AND user_agent = ?", user.ID, true, client.IP, truncateUserAgent(client.UserAgent)).
+		Count(&known)
+
+	g.record(user, email, client, models.LoginResultSuccess)
+
+	// 첫 로그인은 비교할 기록이 없으므로 알리지 않습니다
+	if previous > 0 && known == 0 {
+		go func(to string, client middleware.ClientInfo, at time.Time) {
+			if err := utils.SendNewLoginAlertEmail(to, client.IP, client.UserAgent, at); err != nil {
+				log.Printf("Failed to send new login alert: %v", err)
+			}
+		}(user.Email, client, time.Now())
+	}
+}
+
+func (g *loginGuard) record(user *models.User, email string, client middleware.ClientInfo, result string) {
+	attempt := models.LoginAttempt{
+		Email:     email,
+		IP:        client.IP,
+		UserAgent: truncateUserAgent(client.UserAgent),
+		Success:   result == models.LoginResultSuccess,
+		Result:    result,
+	}
+	if user != nil {
+		attempt.UserID = &user.ID
+	}
+
+	if err := g.db.Create(&attempt).Error; err != nil {
+		log.Printf("Failed to record login attempt: %v", err)
+	}
+}
+
+func truncateUserAgent(userAgent string) string {
+	if len(userAgent) > 512 {
+		return userAgent[:512]
+	}
+	return userAgent
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MagicLinkService struct {
+	db           *gorm.DB
+	links        *emailLinks
+	guard        *loginGuard
+	twoFactor    *TwoFactorService
+	verification config.VerificationConfig
+}
+
+func NewMagicLinkService() *MagicLinkService {
+	cfg := config.LoadConfig()
+	db := database.GetDB()
+	return &MagicLinkService{
+		db:           db,
+		links:        newEmailLinks(magicLink),
+		guard:        newLoginGuard(db, cfg.Login),
+		twoFactor:    NewTwoFactorService(),
+		verification: cfg.Verification,
+	}
+}
+
+// MagicLinkRequest is the request to receive a login link by email
+type MagicLinkRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// VerifyMagicLinkRequest exchanges the token from a login link for tokens
+type VerifyMagicLinkRequest struct {
+	Token string `json:"token" binding:"required"`
+	// 세션 목록에 표시할 기기 이름 (선택)
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"`
+}
+
+// SendMagicLink emails a single-use login link if an account exists for the email.
+// Like ForgotPassword, the result does not reveal whether the account exists.
+func (s *MagicLinkService) SendMagicLink(email, clientIP string) error {
+	return s.links.send(email, clientIP)
+}
+
+// VerifyMagicLink consumes a login link token and logs the user in.
+// Opening the link proves the user owns the email, so an unverified email becomes verified.
+func (s *MagicLinkService) VerifyMagicLink(token string, client middleware.ClientInfo) (*AuthResponse, error) {
+	ctx := context.Background()
+
+	ipLimit, err := hitRateLimit(ctx, fmt.Sprintf("magic_link:ip:verify:%s", client.IP), time.Hour)
+	if err != nil {
+		return nil, errors.NewAppError(500, "요청 제한 확인에 실패했습니다", err.Error())
+	}
+	if ipLimit.Count > int64(s.verification.IPVerifyLimitPerHour) {
+		return nil, errors.ErrTooManyRequests(ipLimit.RetryAfter)
+	}
+	if err := s.guard.checkIP(ctx, client.IP); err != nil {
+		s.guard.blocked("", client, err)
+		return nil, err
+	}
+
+	userID, ok := s.links.consume(ctx, token)
+	if !ok {
+		s.guard.rejected(ctx, nil, "", client, models.LoginResultInvalidMagicLink)
+		return nil, errors.ErrInvalidMagicLink()
+	}
+
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			s.guard.rejected(ctx, nil, "", client, models.LoginResultInvalidMagicLink)
+			return nil, errors.ErrInvalidMagicLink()
+		}
+		return nil, errors.NewAppError(500, "데이터베이스 오류가 발생했습니다", err.Error())
+	}
+
+	if user.EmailVerifiedAt == nil {
+		verifiedAt := time.Now()
+		if err := s.db.Model(&user).Update("email_verified_at", verifiedAt).Error; err != nil {
+			return nil, errors.NewAppError(500, "이메일 인증 처리에 실패했습니다", err.Error())
+		}
+		user.EmailVerifiedAt = &verifiedAt
+	}
+
+	return s.twoFactor.StartLogin(&user, client)
+}
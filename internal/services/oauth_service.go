@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/oauth"
+	"portfolio-server/internal/utils"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 로그인 제공자 화면에서 돌아오기까지 허용하는 시간
+const oauthStateTTL = 10 * time.Minute
+
+var usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+type OAuthService struct {
+	db        *gorm.DB
+	providers map[string]*oauth.Provider
+	guard     *loginGuard
+	twoFactor *TwoFactorService
+}
+
+func NewOAuthService() *OAuthService {
+	cfg := config.LoadConfig()
+	db := database.GetDB()
+	return &OAuthService{
+		db:        db,
+		providers: oauth.NewProviders(cfg.OAuth),
+		guard:     newLoginGuard(db, cfg.Login),
+		twoFactor: NewTwoFactorService(),
+	}
+}
+
+// OAuthAuthorizeResponse carries the provider URL to redirect the user to.
+// The client keeps state and sends it back with the code to the callback endpoint.
+type OAuthAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+	ExpiresIn        int    `json:"expires_in"`
+}
+
+// OAuthCallbackRequest is the code and state the provider redirected back with
+type OAuthCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+	// 세션 목록에 표시할 기기 이름 (선택)
+	DeviceName string `json:"device_name" binding:"omitempty,max=100"`
+}
+
+// oauthState is kept in Redis between the authorize and callback requests
+type oauthState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+func oauthStateKey(state string) string {
+	return fmt.Sprintf("oauth_state:%s", utils.HashToken(state))
+}
+
+// ListProviders returns the names of the configured providers
+func (s *OAuthService) ListProviders() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Authorize starts the authorization code flow with a fresh state, PKCE verifier and nonce
+func (s *OAuthService) Authorize(providerName string) (*OAuthAuthorizeResponse, error) {
+	ctx := context.Background()
+
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, errors.ErrOAuthProviderNotFound()
+	}
+
+	var values [3]string
+	for i := range values {
+		value, err := utils.GenerateSecureToken(32)
+		if err != nil {
+			return nil, errors.NewAppError(500, "인증 토큰 생성에 실패했습니다", err.Error())
+		}
+		values[i] = value
+	}
+	state, codeVerifier, nonce := values[0], values[1], values[2]
+
+	authURL, err := provider.AuthCodeURL(ctx, state, codeVerifier, nonce)
+	if err != nil {
+		return nil, errors.NewAppError(502, "로그인 제공자에 연결할 수 없습니다", err.Error())
+	}
+
+	saved, err := json.Marshal(oauthState{Provider: providerName, CodeVerifier: codeVerifier, Nonce: nonce})
+	if err != nil {
+		return nil, errors.NewAppError(500, "인증 상태 저장에 실패했습니다", err.Error())
+	}
+	if err := database.GetRedis().Set(ctx, oauthStateKey(state), saved, oauthStateTTL).Err(); err != nil {
+		return nil, errors.NewAppError(500, "인증 상태 저장에 실패했습니다", err.Error())
+	}
+
+	return &OAuthAuthorizeResponse{
+		AuthorizationURL: authURL,
+		State:            state,
+		ExpiresIn:        int(oauthStateTTL.Seconds()),
+	}, nil
+}
+
+// Callback redeems the authorization code and logs in the linked user, linking or creating
+// an account on first use. Two-factor authentication applies as with a password login.
+func (s *OAuthService) Callback(providerName string, req *OAuthCallbackRequest, client middleware.ClientInfo) (*AuthResponse, error) {
+	ctx := context.Background()
+
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, errors.ErrOAuthProviderNotFound()
+	}
+	if err := s.guard.checkIP(ctx, client.IP); err != nil {
+		s.guard.blocked("", client, err)
+		return nil, err
+	}
+
+	// state는 한 번만 사용할 수 있습니다
+	value, err := database.GetRedis().GetDel(ctx, oauthStateKey(req.State)).Result()
+	if err != nil {
+		s.guard.rejected(ctx, nil, "", client, models.LoginResultOAuthFailed)
+		return nil, errors.ErrInvalidOAuthState()
+	}
+	var saved oauthState
+	if err := json.Unmarshal([]byte(value), &saved); err != nil || saved.Provider != providerName {
+		s.guard.rejected(ctx, nil, "", client, models.LoginResultOAuthFailed)
+		return nil, errors.ErrInvalidOAuthState()
+	}
+
+	identity, err := provider.Exchange(ctx, req.Code, saved.CodeVerifier, saved.Nonce)
+	if err != nil {
+		s.guard.rejected(ctx, nil, "", client, models.LoginResultOAuthFailed)
+		return nil, errors.ErrOAuthFailed(err.Error())
+	}
+
+	user, err := s.findOrCreateUser(providerName, identity)
+	if err != nil {
+		// 서버 오류가 아니라 계정에 연결할 수 없어 거절된 경우만 실패로 기록합니다
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code < 500 {
+			s.guard.rejected(ctx, nil, identity.Email, client, models.LoginResultOAuthFailed)
+		}
+		return nil, err
+	}
+
+	return s.twoFactor.StartLogin(user, client)
+}
+
+// findOrCreateUser resolves the user for a provider identity.
+// An identity that is not linked yet is linked to the account with the same email, but only when both
+// the provider and this server have verified that email; otherwise anyone could take over an account
+// by signing up at a provider with someone else's address.
+func (s *OAuthService) findOrCreateUser(providerName string, identity *oauth.Identity) (*models.User, error) {
+	var link models.UserIdentity
+	err := s.db.Where("provider = ? AND subject = ?", providerName, identity.Subject).First(&link).Error
+	if err == nil {
+		var user models.User
+		if err := s.db.First(&user, link.UserID).Error; err == nil {
+			return &user, nil
+		} else if err != gorm.ErrRecordNotFound {
+			return nil, errors.NewAppError(500, "데이터베이스 오류가 발생했습니다", err.Error())
+		}
+		// 연결된 계정이 삭제되었으면 처음 로그인하는 것으로 처리합니다
+		s.db.Delete(&link)
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, errors.NewAppError(500, "데이터베이스 오류가 발생했습니다", err.Error())
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, errors.ErrOAuthEmailNotVerified()
+	}
+
+	var user models.User
+	err = s.db.Where("email = ?", identity.Email).First(&user).Error
+	switch {
+	case err == nil:
+		if user.EmailVerifiedAt == nil {
+			return nil, errors.ErrOAuthAccountNotLinkable()
+		}
+	case err == gorm.ErrRecordNotFound:
+		verifiedAt := time.Now()
+		user = models.User{
+			Email:    identity.Email,
+			Username: s.availableUsername(identity),
+			// 소셜 로그인으로 가입한 계정은 비밀번호 재설정으로 비밀번호를 만들 수 있습니다
+			Password:        "",
+			EmailVerifiedAt: &verifiedAt,
+		}
+	default:
+		return nil, errors.NewAppError(500, "데이터베이스 오류가 발생했습니다", err.Error())
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if user.ID == 0 {
+			if err := tx.Create(&user).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&models.UserIdentity{
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+		}).Error
+	})
+	if err != nil {
+		return nil, errors.NewAppError(500, "소셜 로그인 계정 연결에 실패했습니다", err.Error())
+	}
+
+	return &user, nil
+}
+
+// availableUsername derives an unused username from the provider profile
+func (s *OAuthService) availableUsername(identity *oauth.Identity) string {
+	base := identity.PreferredUsername
+	if base == "" {
+		base = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+	base = usernameInvalidChars.ReplaceAllString(base, "_")
+	if len(base) > 20 {
+		base = base[:20]
+	}
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	candidate := base
+	for i := 0; i < 5; i++ {
+		var count int64
+		s.db.Model(&models.User{}).Unscoped().Where("username = ?", candidate).Count(&count)
+		if count == 0 {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s_%s", base, uuid.NewString()[:6])
+	}
+	return fmt.Sprintf("user_%s", strings.ReplaceAll(uuid.NewString(), "-", "")[:20])
+}
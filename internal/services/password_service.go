@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type PasswordService struct {
+	db    *gorm.DB
+	links *emailLinks
+}
+
+func NewPasswordService() *PasswordService {
+	return &PasswordService{
+		db:    database.GetDB(),
+		links: newEmailLinks(passwordResetLink),
+	}
+}
+
+// ForgotPasswordRequest is the request to receive a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest is the request to set a new password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+// ForgotPassword emails a reset link if an account exists for the email.
+// The result is the same whether or not the account exists so the endpoint cannot be used to find users.
+func (s *PasswordService) ForgotPassword(email, clientIP string) error {
+	return s.links.send(email, clientIP)
+}
+
+// ResetPassword sets a new password with a reset token and revokes every existing session
+func (s *PasswordService) ResetPassword(token, newPassword string) error {
+	ctx := context.Background()
+
+	userID, ok := s.links.consume(ctx, token)
+	if !ok {
+		return errors.ErrInvalidPasswordResetToken()
+	}
+
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrInvalidPasswordResetToken()
+		}
+		return errors.NewAppError(500, "데이터베이스 오류가 발생했습니다", err.Error())
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return errors.NewAppError(500, "비밀번호 암호화에 실패했습니다", err.Error())
+	}
+
+	if err := s.db.Model(&user).Update("password", hashedPassword).Error; err != nil {
+		return errors.NewAppError(500, "비밀번호 변경에 실패했습니다", err.Error())
+	}
+
+	if err := middleware.RevokeAllSessions(ctx, user.ID); err != nil {
+		return errors.NewAppError(500, "세션 종료에 실패했습니다", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// 일별 활동은 날짜별 sorted set에 글 ID를 멤버로 쌓고, RankingService가 주기적으로 합산합니다.
+//
+//	article_views:daily:<YYYY-MM-DD>     그날 글별 조회수 (중복 제외)
+//	article_comments:daily:<YYYY-MM-DD>  그날 글별 댓글 수
+//	article_reactions:daily:<YYYY-MM-DD> 그날 글별 반응 수
+//	article_ranking:trending             시간 감쇠를 적용한 인기 글 점수
+//	article_ranking:<period>             기간별 조회수 순위 (day, week, month, all)
+//	article_ranking:updated_at           마지막으로 계산한 시각 (unix)
+const (
+	dailyViewsKeyPrefix     = "article_views:daily:"
+	dailyCommentsKeyPrefix  = "article_comments:daily:"
+	dailyReactionsKeyPrefix = "article_reactions:daily:"
+	trendingKey             = "article_ranking:trending"
+	rankingUpdatedAtKey     = "article_ranking:updated_at"
+)
+
+// 월간 순위에 필요한 기간보다 조금 더 보관합니다
+const dailyActivityTTL = 32 * 24 * time.Hour
+
+// leaderboardDays is how many days of daily views each period adds up; all uses the all-time ranking
+var leaderboardDays = map[string]int{
+	"day":   1,
+	"week":  7,
+	"month": 30,
+}
+
+func dailyKey(prefix string, day time.Time) string {
+	return prefix + day.Format("2006-01-02")
+}
+
+func leaderboardKey(period string) string {
+	return "article_ranking:" + period
+}
+
+// recordDailyActivity adds one view, comment or reaction (depending on prefix) to the article's count for the day
+func recordDailyActivity(ctx context.Context, prefix string, articleID uint, at time.Time) {
+	key := dailyKey(prefix, at)
+	pipe := database.GetRedis().Pipeline()
+	pipe.ZIncrBy(ctx, key, 1, strconv.FormatUint(uint64(articleID), 10))
+	pipe.Expire(ctx, key, dailyActivityTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to record article activity: %v", err)
+	}
+}
+
+type rankedArticle struct {
+	article models.Article
+	score   float64
+}
+
+// publicRankedArticles reads the top of a ranking sorted set and returns up to limit articles that are
+// still public, in ranking order. Articles deleted or unpublished since they were ranked are skipped.
+func publicRankedArticles(ctx context.Context, db *gorm.DB, key string, limit int) ([]rankedArticle, error) {
+	// 걸러지는 글이 있어도 limit개가 남도록 넉넉히 가져옵니다
+	ranking, err := database.GetRedis().ZRevRangeWithScores(ctx, key, 0, int64(limit*4-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(ranking))
+	scores := make(map[uint]float64, len(ranking))
+	for _, entry := range ranking {
+		member, _ := entry.Member.(string)
+		if id, err := strconv.ParseUint(member, 10, 32); err == nil {
+			ids = append(ids, uint(id))
+			scores[uint(id)] = entry.Score
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var articles []models.Article
+	if err := db.Select("id", "title", "slug", "status", "published_at").Scopes(publicArticles(time.Now())).
+		Where("id IN ?", ids).Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	found := make(map[uint]*models.Article, len(articles))
+	for i := range articles {
+		found[articles[i].ID] = &articles[i]
+	}
+
+	ranked := make([]rankedArticle, 0, limit)
+	for _, id := range ids {
+		article, ok := found[id]
+		if !ok {
+			continue
+		}
+		ranked = append(ranked, rankedArticle{article: *article, score: scores[id]})
+		if len(ranked) == limit {
+			break
+		}
+	}
+	return ranked, nil
+}
+
+type RankingService struct {
+	db  *gorm.DB
+	cfg config.RankingConfig
+}
+
+func NewRankingService() *RankingService {
+	cfg := config.LoadConfig().Ranking
+	if cfg.Size <= 0 {
+		cfg.Size = 10
+	}
+	if cfg.TrendingDays <= 0 {
+		cfg.TrendingDays = 7
+	}
+	if cfg.TrendingHalfLifeHours <= 0 {
+		cfg.TrendingHalfLifeHours = 24
+	}
+	return &RankingService{
+		db:  database.GetDB(),
+		cfg: cfg,
+	}
+}
+
+// RankingQuery is the query string of the trending and leaderboard endpoints
+type RankingQuery struct {
+	// 기간별 순위에만 사용합니다 (기본값: week)
+	Period string `form:"period" binding:"omitempty,oneof=day week month all"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1"`
+}
+
+// Trending returns the articles with the highest time-decayed score of recent views, comments and reactions
+func (s *RankingService) Trending(query *RankingQuery) (*models.RankingResponse, error) {
+	return s.ranking(trendingKey, "trending", query.Limit)
+}
+
+// Leaderboard returns the most viewed articles of the period
+func (s *RankingService) Leaderboard(query *RankingQuery) (*models.RankingResponse, error) {
+	period := query.Period
+	if period == "" {
+		period = "week"
+	}
+	return s.ranking(leaderboardKey(period), period, query.Limit)
+}
+
+func (s *RankingService) ranking(key, period string, limit int) (*models.RankingResponse, error) {
+	ctx := context.Background()
+	rdb := database.GetRedis()
+
+	if limit <= 0 || limit > s.cfg.Size {
+		limit = s.cfg.Size
+	}
+
+	updatedAt, err := rdb.Get(ctx, rankingUpdatedAtKey).Int64()
+	if err == redis.Nil {
+		// 아직 한 번도 계산하지 않았으면 지금 계산합니다
+		now := time.Now()
+		if err := s.Refresh(ctx, now); err != nil {
+			return nil, fmt.Errorf("순위 계산 실패: %w", err)
+		}
+		updatedAt, err = now.Unix(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("순위 조회 실패: %w", err)
+	}
+
+	ranked, err := publicRankedArticles(ctx, s.db, key, limit)
+	if err != nil {
+		return nil, fmt.Errorf("순위 조회 실패: %w", err)
+	}
+
+	articles := make([]models.RankedArticleInfo, len(ranked))
+	for i, entry := range ranked {
+		articles[i] = models.RankedArticleInfo{
+			Rank:  i + 1,
+			ID:    entry.article.ID,
+			Title: entry.article.Title,
+			Slug:  entry.article.Slug,
+			Score: math.Round(entry.score*100) / 100,
+		}
+	}
+
+	at := time.Unix(updatedAt, 0)
+	return &models.RankingResponse{
+		Period:    period,
+		Articles:  articles,
+		UpdatedAt: &at,
+	}, nil
+}
+
+// Refresh recomputes the trending scores and leaderboards from the daily activity.
+// Each ranking is built in a temporary key and renamed into place, so readers never see a partial ranking.
+func (s *RankingService) Refresh(ctx context.Context, now time.Time) error {
+	trending := &redis.ZStore{Aggregate: "SUM"}
+	for i := 0; i < s.cfg.TrendingDays; i++ {
+		day := now.AddDate(0, 0, -i)
+		// 하루 지날 때마다 24/반감기 만큼 가중치가 줄어듭니다
+		decay := math.Pow(0.5, float64(i*24)/float64(s.cfg.TrendingHalfLifeHours))
+		trending.Keys = append(trending.Keys,
+			dailyKey(dailyViewsKeyPrefix, day), dailyKey(dailyCommentsKeyPrefix, day), dailyKey(dailyReactionsKeyPrefix, day))
+		trending.Weights = append(trending.Weights,
+			decay, decay*float64(s.cfg.CommentWeight), decay*float64(s.cfg.ReactionWeight))
+	}
+	if err := storeRanking(ctx, trendingKey, trending); err != nil {
+		return err
+	}
+
+	for period, days := range leaderboardDays {
+		views := &redis.ZStore{Aggregate: "SUM"}
+		for i := 0; i < days; i++ {
+			views.Keys = append(views.Keys, dailyKey(dailyViewsKeyPrefix, now.AddDate(0, 0, -i)))
+		}
+		if err := storeRanking(ctx, leaderboardKey(period), views); err != nil {
+			return err
+		}
+	}
+	if err := storeRanking(ctx, leaderboardKey("all"), &redis.ZStore{Keys: []string{viewRankingKey}}); err != nil {
+		return err
+	}
+
+	return database.GetRedis().Set(ctx, rankingUpdatedAtKey, now.Unix(), 0).Err()
+}
+
+func storeRanking(ctx context.Context, key string, store *redis.ZStore) error {
+	rdb := database.GetRedis()
+	tmp := fmt.Sprintf("%s:tmp:%s", key, uuid.NewString())
+
+	count, err := rdb.ZUnionStore(ctx, tmp, store).Result()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return rdb.Del(ctx, key).Err()
+	}
+	return rdb.Rename(ctx, tmp, key).Err()
+}
+
+// RankingRefresher recomputes the rankings in the background
+type RankingRefresher struct {
+	ranking  *RankingService
+	interval time.Duration
+}
+
+func NewRankingRefresher() *RankingRefresher {
+	interval := time.Duration(config.LoadConfig().Ranking.RefreshIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	return &RankingRefresher{
+		ranking:  NewRankingService(),
+		interval: interval,
+	}
+}
+
+// Start refreshes the rankings now and then every interval until ctx is cancelled
+func (r *RankingRefresher) Start(ctx context.Context) {
+	runEvery(ctx, r.interval, func(ctx context.Context) {
+		if err := r.ranking.Refresh(ctx, time.Now()); err != nil {
+			log.Printf("Failed to refresh article rankings: %v", err)
+		}
+	})
+}
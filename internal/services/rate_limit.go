@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"portfolio-server/internal/database"
+	"time"
+)
+
+// rateLimitResult describes a fixed window counter after it has been incremented
+type rateLimitResult struct {
+	Count      int64
+	RetryAfter int
+}
+
+// hitRateLimit increments the counter stored at key. The window starts with the first hit
+// and the counter is dropped by Redis when the window expires.
+func hitRateLimit(ctx context.Context, key string, window time.Duration) (*rateLimitResult, error) {
+	rdb := database.GetRedis()
+
+	count, err := rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if count == 1 {
+		if err := rdb.Expire(ctx, key, window).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &rateLimitResult{
+		Count:      count,
+		RetryAfter: retryAfterSeconds(ctx, key, window),
+	}, nil
+}
+
+// acquireCooldown sets key for the given duration unless it already exists.
+// When the cooldown is still running it returns false and the seconds left.
+func acquireCooldown(ctx context.Context, key string, cooldown time.Duration) (bool, int, error) {
+	ok, err := database.GetRedis().SetNX(ctx, key, 1, cooldown).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	if ok {
+		return true, 0, nil
+	}
+	return false, retryAfterSeconds(ctx, key, cooldown), nil
+}
+
+func retryAfterSeconds(ctx context.Context, key string, fallback time.Duration) int {
+	ttl, err := database.GetRedis().TTL(ctx, key).Result()
+	if err != nil || ttl <= 0 {
+		ttl = fallback
+	}
+	return int(ttl.Round(time.Second).Seconds())
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// adjustReactionCount changes one reaction's count in the reaction_counts column of a table by delta.
+// The update reads the current value in the same statement, so concurrent reactions never lose a count;
+// a count that reaches zero is removed from the object.
+const adjustReactionCount = `UPDATE %s SET reaction_counts = CASE
+	WHEN COALESCE((reaction_counts->>CAST(? AS text))::int, 0) + CAST(? AS int) > 0
+	THEN jsonb_set(COALESCE(reaction_counts, '{}'::jsonb), ARRAY[CAST(? AS text)],
+		to_jsonb(COALESCE((reaction_counts->>CAST(? AS text))::int, 0) + CAST(? AS int)))
+	ELSE COALESCE(reaction_counts, '{}'::jsonb) - CAST(? AS text)
+	END
+	WHERE id = ?`
+
+// reactionCounts never returns nil so responses always have a reactions object
+func reactionCounts(counts map[string]int) map[string]int {
+	if counts == nil {
+		return map[string]int{}
+	}
+	return counts
+}
+
+// viewerReactions returns the reactions the user left on each of the targets, keyed by target ID
+func viewerReactions(db *gorm.DB, userID uint, targetType string, targetIDs []uint) (map[uint][]string, error) {
+	result := make(map[uint][]string)
+	if userID == 0 || len(targetIDs) == 0 {
+		return result, nil
+	}
+
+	var reactions []models.Reaction
+	if err := db.Select("target_id", "type").
+		Where("user_id = ? AND target_type = ? AND target_id IN ?", userID, targetType, targetIDs).
+		Order("id ASC").Find(&reactions).Error; err != nil {
+		return nil, err
+	}
+	for _, reaction := range reactions {
+		result[reaction.TargetID] = append(result[reaction.TargetID], string(reaction.Type))
+	}
+	return result, nil
+}
+
+type ReactionService struct {
+	db *gorm.DB
+}
+
+func NewReactionService() *ReactionService {
+	return &ReactionService{
+		db: database.GetDB(),
+	}
+}
+
+// AddArticleReaction reacts to an article the viewer can see. Reacting twice with the same reaction
+// changes nothing, so clients can retry safely.
+func (s *ReactionService) AddArticleReaction(articleID uint, reaction models.ReactionType, actor policy.Actor) (*models.ReactionSummary, error) {
+	if _, err := s.visibleArticle(articleID, actor); err != nil {
+		return nil, err
+	}
+
+	added, err := s.setReaction(models.ReactionTargetArticle, articleID, reaction, actor.UserID, true)
+	if err != nil {
+		return nil, err
+	}
+	if added {
+		recordDailyActivity(context.Background(), dailyReactionsKeyPrefix, articleID, time.Now())
+	}
+
+	return s.summary(models.ReactionTargetArticle, articleID, actor.UserID)
+}
+
+// RemoveArticleReaction removes the viewer's reaction from an article. Removing a reaction that
+// does not exist is not an error.
+func (s *ReactionService) RemoveArticleReaction(articleID uint, reaction models.ReactionType, actor policy.Actor) (*models.ReactionSummary, error) {
+	if _, err := s.visibleArticle(articleID, actor); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.setReaction(models.ReactionTargetArticle, articleID, reaction, actor.UserID, false); err != nil {
+		return nil, err
+	}
+
+	return s.summary(models.ReactionTargetArticle, articleID, actor.UserID)
+}
+
+// AddCommentReaction reacts to a comment of a public article
+func (s *ReactionService) AddCommentReaction(articleID, commentID uint, reaction models.ReactionType, actor policy.Actor) (*models.ReactionSummary, error) {
+	if err := s.visibleComment(articleID, commentID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.setReaction(models.ReactionTargetComment, commentID, reaction, actor.UserID, true); err != nil {
+		return nil, err
+	}
+
+	return s.summary(models.ReactionTargetComment, commentID, actor.UserID)
+}
+
+// RemoveCommentReaction removes the viewer's reaction from a comment
+func (s *ReactionService) RemoveCommentReaction(articleID, commentID uint, reaction models.ReactionType, actor policy.Actor) (*models.ReactionSummary, error) {
+	if err := s.visibleComment(articleID, commentID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.setReaction(models.ReactionTargetComment, commentID, reaction, actor.UserID, false); err != nil {
+		return nil, err
+	}
+
+	return s.summary(models.ReactionTargetComment, commentID, actor.UserID)
+}
+
+// visibleArticle loads an article the actor may read. Unpublished articles of others are reported as not found.
+func (s *ReactionService) visibleArticle(articleID uint, actor policy.Actor) (*models.Article, error) {
+	var article models.Article
+	if err := s.db.First(&article, articleID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrArticleNotFound()
+		}
+		return nil, fmt.Errorf("게시글 조회 실패: %w", err)
+	}
+	if !policy.CanViewArticle(actor, &article, time.Now()) {
+		return nil, errors.ErrArticleNotFound()
+	}
+	return &article, nil
+}
+
+// visibleComment checks that the comment exists, belongs to the article in the path and that the article
+// is public, like the comment list
+func (s *ReactionService) visibleComment(articleID, commentID uint) error {
+	var comment models.Comment
+	if err := s.db.First(&comment, commentID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrCommentNotFound()
+		}
+		return fmt.Errorf("댓글 조회 실패: %w", err)
+	}
+	if comment.ArticleID != articleID {
+		return errors.ErrCommentNotFound()
+	}
+
+	var count int64
+	if err := s.db.Model(&models.Article{}).Scopes(publicArticles(time.Now())).
+		Where("id = ?", comment.ArticleID).Count(&count).Error; err != nil {
+		return fmt.Errorf("게시글 조회 실패: %w", err)
+	}
+	if count == 0 {
+		return errors.ErrCommentNotFound()
+	}
+	return nil
+}
+
+// setReaction adds or removes a reaction and reports whether anything changed.
+// The counter is only adjusted when the row was actually inserted or deleted, so repeated and
+// concurrent requests of the same user are counted once.
+func (s *ReactionService) setReaction(targetType string, targetID uint, reaction models.ReactionType, userID uint, add bool) (bool, error) {
+	table := "articles"
+	if targetType == models.ReactionTargetComment {
+		table = "comments"
+	}
+
+	changed := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var result *gorm.DB
+		delta := 1
+		if add {
+			result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Reaction{
+				UserID:     userID,
+				TargetType: targetType,
+				TargetID:   targetID,
+				Type:       reaction,
+			})
+		} else {
+			delta = -1
+			result = tx.Where("user_id = ? AND target_type = ? AND target_id = ? AND type = ?", userID, targetType, targetID, reaction).
+				Delete(&models.Reaction{})
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		changed = true
+		key := string(reaction)
+		return tx.Exec(fmt.Sprintf(adjustReactionCount, table), key, delta, key, key, delta, key, targetID).Error
+	})
+	if err != nil {
+		return false, fmt.Errorf("반응 저장 실패: %w", err)
+	}
+	return changed, nil
+}
+
+func (s *ReactionService) summary(targetType string, targetID uint, userID uint) (*models.ReactionSummary, error) {
+	var counts struct {
+		ReactionCounts map[string]int `gorm:"serializer:json"`
+	}
+	query := s.db.Model(&models.Article{})
+	if targetType == models.ReactionTargetComment {
+		query = s.db.Model(&models.Comment{})
+	}
+	if err := query.Select("reaction_counts").Where("id = ?", targetID).Take(&counts).Error; err != nil {
+		return nil, fmt.Errorf("반응 조회 실패: %w", err)
+	}
+
+	mine, err := viewerReactions(s.db, userID, targetType, []uint{targetID})
+	if err != nil {
+		return nil, fmt.Errorf("반응 조회 실패: %w", err)
+	}
+
+	return &models.ReactionSummary{
+		Reactions:       reactionCounts(counts.ReactionCounts),
+		ViewerReactions: append([]string{}, mine[targetID]...),
+	}, nil
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ts_headline이 일치한 단어를 감싸는 표시. 본문을 HTML 이스케이프한 뒤 <mark>로 바꿉니다
+const (
+	headlineStart = "⟦"
+	headlineStop  = "⟧"
+)
+
+const headlineOptions = "StartSel=" + headlineStart + ", StopSel=" + headlineStop +
+	", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \""
+
+type SearchService struct {
+	db  *gorm.DB
+	cfg config.SearchConfig
+}
+
+func NewSearchService() *SearchService {
+	return &SearchService{
+		db:  database.GetDB(),
+		cfg: config.LoadConfig().Search,
+	}
+}
+
+// SearchRequest is the query string of GET /search. From and To are inclusive dates.
+type SearchRequest struct {
+	Query      string     `form:"q" binding:"required,max=200"`
+	Type       string     `form:"type" binding:"omitempty,oneof=all articles comments"`
+	CategoryID *uint      `form:"category_id"`
+	AuthorID   *uint      `form:"author_id"`
+	From       *time.Time `form:"from" time_format:"2006-01-02"`
+	To         *time.Time `form:"to" time_format:"2006-01-02"`
+	Limit      int        `form:"limit"`
+	Offset     int        `form:"offset" binding:"omitempty,min=0"`
+}
+
+// searchRow is a row of the search query before the snippet is built
+type searchRow struct {
+	Type      string
+	ArticleID uint
+	CommentID *uint
+	Title     string
+	Slug      string
+	AuthorID  uint
+	CreatedAt time.Time
+	Rank      float64
+	Headline  string
+	Excerpt   string
+}
+
+// Search finds public articles and comments on public articles, best match first.
+// Matches come from the tsvector columns and, when enabled, from a substring match of the whole query.
+func (s *SearchService) Search(req *SearchRequest) (*models.SearchResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, errors.ErrInvalidInput("검색어를 입력해주세요")
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > 50 {
+		limit = 20
+	}
+
+	args := map[string]interface{}{
+		"config":  s.cfg.TextSearchConfig,
+		"q":       query,
+		"pattern": "%" + escapeLike(query) + "%",
+		"options": headlineOptions,
+		"now":     time.Now(),
+		"limit":   limit + 1,
+		"offset":  req.Offset,
+	}
+
+	var filters []string
+	if req.CategoryID != nil {
+		filters = append(filters, "EXISTS (SELECT 1 FROM article_categories ac WHERE ac.article_id = articles.id AND ac.category_id = @category_id)")
+		args["category_id"] = *req.CategoryID
+	}
+	if req.From != nil {
+		args["from"] = *req.From
+	}
+	if req.To != nil {
+		args["to"] = req.To.AddDate(0, 0, 1)
+	}
+
+	var parts []string
+	if req.Type != "comments" {
+		parts = append(parts, s.articleQuery(filters, req))
+	}
+	if req.Type != "articles" {
+		parts = append(parts, s.commentQuery(filters, req))
+	}
+	if req.AuthorID != nil {
+		args["author_id"] = *req.AuthorID
+	}
+
+	// 스니펫은 비용이 크므로 정렬과 LIMIT 이후의 행에 대해서만 만듭니다
+	sql := fmt.Sprintf(`SELECT results.*,
+			ts_headline(CAST(@config AS regconfig), body.content, websearch_to_tsquery(CAST(@config AS regconfig), @q), @options) AS headline,
+			substring(body.content from greatest(strpos(lower(body.content), lower(@q)) - 60, 1) for 200) AS excerpt
+		FROM (SELECT * FROM (%s) matches ORDER BY rank DESC, created_at DESC LIMIT @limit OFFSET @offset) results
+		CROSS JOIN LATERAL (
+			SELECT COALESCE((SELECT content FROM comments WHERE comments.id = results.comment_id),
+				(SELECT content FROM articles WHERE articles.id = results.article_id)) AS content
+		) body
+		ORDER BY results.rank DESC, results.created_at DESC`, strings.Join(parts, " UNION ALL "))
+
+	var rows []searchRow
+	if err := s.db.Raw(sql, args).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("검색 실패: %w", err)
+	}
+
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	authorIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		authorIDs = append(authorIDs, row.AuthorID)
+	}
+	var authors []models.User
+	if len(authorIDs) > 0 {
+		if err := s.db.Where("id IN ?", authorIDs).Find(&authors).Error; err != nil {
+			return nil, fmt.Errorf("작성자 조회 실패: %w", err)
+		}
+	}
+	authorsByID := make(map[uint]models.User, len(authors))
+	for _, author := range authors {
+		authorsByID[author.ID] = author
+	}
+
+	results := make([]models.SearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = models.SearchResult{
+			Type:       row.Type,
+			ArticleID:  row.ArticleID,
+			CommentID:  row.CommentID,
+			Title:      row.Title,
+			Slug:       row.Slug,
+			AuthorID:   row.AuthorID,
+			AuthorName: authorName(authorsByID[row.AuthorID]),
+			Snippet:    searchSnippet(row.Headline, row.Excerpt, query),
+			Rank:       row.Rank,
+			CreatedAt:  row.CreatedAt,
+		}
+	}
+
+	return &models.SearchResponse{
+		Query:   query,
+		Results: results,
+		HasMore: hasMore,
+	}, nil
+}
+
+// matchCondition matches the tsvector column and, if enabled, the query as a substring of the given columns
+func (s *SearchService) matchCondition(vector string, columns ...string) string {
+	conditions := []string{vector + " @@ query"}
+	if s.cfg.SubstringMatch {
+		for _, column := range columns {
+			conditions = append(conditions, column+" ILIKE @pattern")
+		}
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+func (s *SearchService) articleQuery(filters []string, req *SearchRequest) string {
+	conditions := append([]string{
+		"articles.deleted_at IS NULL",
+		strings.Replace(publicArticleCondition, "?", "@now", 1),
+		s.matchCondition("articles.search_vector", "articles.title", "articles.content"),
+	}, filters...)
+	if req.AuthorID != nil {
+		conditions = append(conditions, "articles.author_id = @author_id")
+	}
+	if req.From != nil {
+		conditions = append(conditions, "COALESCE(articles.published_at, articles.created_at) >= @from")
+	}
+	if req.To != nil {
+		conditions = append(conditions, "COALESCE(articles.published_at, articles.created_at) < @to")
+	}
+
+	// 제목에 검색어가 그대로 들어 있으면 순위를 높입니다
+	return `SELECT 'article' AS type, articles.id AS article_id, NULL::bigint AS comment_id,
+			articles.title, articles.slug, articles.author_id,
+			COALESCE(articles.published_at, articles.created_at) AS created_at,
+			ts_rank_cd(articles.search_vector, query) + CASE WHEN articles.title ILIKE @pattern THEN 0.5 ELSE 0 END AS rank
+		FROM articles CROSS JOIN websearch_to_tsquery(CAST(@config AS regconfig), @q) AS query
+		WHERE ` + strings.Join(conditions, " AND ")
+}
+
+func (s *SearchService) commentQuery(filters []string, req *SearchRequest) string {
+	conditions := append([]string{
+		"articles.deleted_at IS NULL",
+		strings.Replace(publicArticleCondition, "?", "@now", 1),
+		s.matchCondition("comments.search_vector", "comments.content"),
+	}, filters...)
+	if req.AuthorID != nil {
+		conditions = append(conditions, "comments.author_id = @author_id")
+	}
+	if req.From != nil {
+		conditions = append(conditions, "comments.created_at >= @from")
+	}
+	if req.To != nil {
+		conditions = append(conditions, "comments.created_at < @to")
+	}
+
+	return `SELECT 'comment' AS type, comments.article_id, comments.id AS comment_id,
+			articles.title, articles.slug, comments.author_id, comments.created_at,
+			ts_rank_cd(comments.search_vector, query) AS rank
+		FROM comments JOIN articles ON articles.id = comments.article_id
+			CROSS JOIN websearch_to_tsquery(CAST(@config AS regconfig), @q) AS query
+		WHERE ` + strings.Join(conditions, " AND ")
+}
+
+// searchSnippet returns HTML-escaped text with the matches wrapped in <mark>.
+// It uses the ts_headline fragment when it highlighted a word, otherwise the excerpt around the
+// substring match (e.g. "언어" inside "언어는", which the tsvector does not split).
+func searchSnippet(headline, excerpt, query string) string {
+	if strings.Contains(headline, headlineStart) {
+		escaped := html.EscapeString(headline)
+		return strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>").Replace(escaped)
+	}
+
+	lowerExcerpt, lowerQuery := strings.ToLower(excerpt), strings.ToLower(query)
+	// 소문자로 바꾸며 길이가 달라지는 문자가 있으면 위치를 맞출 수 없으므로 표시 없이 반환합니다
+	if lowerQuery == "" || len(lowerExcerpt) != len(excerpt) {
+		return html.EscapeString(excerpt)
+	}
+
+	var b strings.Builder
+	rest, lowerRest := excerpt, lowerExcerpt
+	for {
+		i := strings.Index(lowerRest, lowerQuery)
+		if i < 0 {
+			b.WriteString(html.EscapeString(rest))
+			return b.String()
+		}
+		b.WriteString(html.EscapeString(rest[:i]))
+		b.WriteString("<mark>" + html.EscapeString(rest[i:i+len(lowerQuery)]) + "</mark>")
+		rest, lowerRest = rest[i+len(lowerQuery):], lowerRest[i+len(lowerQuery):]
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in s
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,372 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 일별 통계의 재료는 Redis에 모았다가 StatsRollup이 article_daily_stats에 집계합니다.
+// 조회수는 article_views:daily:<YYYY-MM-DD>를 그대로 사용합니다.
+//
+//	article_stats:visitors:<YYYY-MM-DD>:<article_id>   그날의 방문자 (HyperLogLog)
+//	article_stats:referrers:<YYYY-MM-DD>:<article_id>  그날의 유입 경로별 조회수 (hash)
+const (
+	dailyVisitorsKeyPrefix  = "article_stats:visitors:"
+	dailyReferrersKeyPrefix = "article_stats:referrers:"
+)
+
+// 전날 통계를 마지막으로 집계할 때까지만 보관하면 됩니다
+const dailyStatsTTL = 3 * 24 * time.Hour
+
+// 하루 통계에 저장하는 유입 경로 수
+const maxDailyReferrers = 20
+
+// 한 번에 조회할 수 있는 최대 일수
+const maxStatsDays = 366
+
+func dailyArticleKey(prefix string, day time.Time, articleID uint) string {
+	return fmt.Sprintf("%s%s:%d", prefix, day.Format("2006-01-02"), articleID)
+}
+
+// statsDate is the date column value for the local calendar day of t
+func statsDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// referrerHost reduces a Referer header to the host the visitor came from.
+// Visits without a referrer are "direct" and visits from the frontend itself are "internal".
+func referrerHost(referrer, ownHost string) string {
+	parsed, err := url.Parse(referrer)
+	if referrer == "" || err != nil || parsed.Hostname() == "" {
+		return "direct"
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if host == ownHost {
+		return "internal"
+	}
+	return host
+}
+
+// recordVisit adds the visitor to the article's unique visitors of the day and, for a counted view,
+// the view to its referrer
+func recordVisit(ctx context.Context, articleID uint, visitor, referrer string, counted bool, at time.Time) {
+	pipe := database.GetRedis().Pipeline()
+	visitorsKey := dailyArticleKey(dailyVisitorsKeyPrefix, at, articleID)
+	pipe.PFAdd(ctx, visitorsKey, visitor)
+	pipe.Expire(ctx, visitorsKey, dailyStatsTTL)
+	if counted {
+		referrersKey := dailyArticleKey(dailyReferrersKeyPrefix, at, articleID)
+		pipe.HIncrBy(ctx, referrersKey, referrer, 1)
+		pipe.Expire(ctx, referrersKey, dailyStatsTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to record article visit: %v", err)
+	}
+}
+
+// StatsRollup periodically writes the daily view counts collected in Redis to article_daily_stats.
+// Today and yesterday are rolled up on every run so the last views of a day are not lost at midnight.
+type StatsRollup struct {
+	db       *gorm.DB
+	interval time.Duration
+}
+
+func NewStatsRollup() *StatsRollup {
+	interval := time.Duration(config.LoadConfig().View.RollupIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	return &StatsRollup{
+		db:       database.GetDB(),
+		interval: interval,
+	}
+}
+
+// Start rolls up yesterday and today in the background until ctx is cancelled.
+// The rollup overwrites rows with the totals kept in Redis, so running it again is harmless.
+func (r *StatsRollup) Start(ctx context.Context) {
+	runEvery(ctx, r.interval, func(ctx context.Context) {
+		now := time.Now()
+		for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+			if err := r.RollupDay(ctx, day); err != nil {
+				log.Printf("Failed to roll up article stats: %v", err)
+			}
+		}
+	})
+}
+
+// RollupDay upserts the stats of every article viewed on the given day
+func (r *StatsRollup) RollupDay(ctx context.Context, day time.Time) error {
+	rdb := database.GetRedis()
+
+	views, err := rdb.ZRangeWithScores(ctx, dailyKey(dailyViewsKeyPrefix, day), 0, -1).Result()
+	if err != nil {
+		return err
+	}
+	if len(views) == 0 {
+		return nil
+	}
+
+	type articleKeys struct {
+		visitors  *redis.IntCmd
+		referrers *redis.MapStringStringCmd
+	}
+	pipe := rdb.Pipeline()
+	keys := make([]articleKeys, len(views))
+	ids := make([]uint, len(views))
+	for i, entry := range views {
+		member, _ := entry.Member.(string)
+		id, _ := strconv.ParseUint(member, 10, 32)
+		ids[i] = uint(id)
+		keys[i] = articleKeys{
+			visitors:  pipe.PFCount(ctx, dailyArticleKey(dailyVisitorsKeyPrefix, day, ids[i])),
+			referrers: pipe.HGetAll(ctx, dailyArticleKey(dailyReferrersKeyPrefix, day, ids[i])),
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return err
+	}
+
+	date := statsDate(day)
+	stats := make([]models.ArticleDailyStat, 0, len(views))
+	for i, entry := range views {
+		if ids[i] == 0 {
+			continue
+		}
+		stats = append(stats, models.ArticleDailyStat{
+			ArticleID:      ids[i],
+			Date:           date,
+			Views:          int(entry.Score),
+			UniqueVisitors: int(keys[i].visitors.Val()),
+			Referrers:      topReferrers(keys[i].referrers.Val()),
+		})
+	}
+
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "article_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"views", "unique_visitors", "referrers", "updated_at"}),
+	}).CreateInBatches(&stats, 500).Error
+}
+
+// topReferrers keeps the maxDailyReferrers referrers with the most views
+func topReferrers(values map[string]string) map[string]int {
+	referrers := make([]models.ReferrerStat, 0, len(values))
+	for host, value := range values {
+		if count, err := strconv.Atoi(value); err == nil {
+			referrers = append(referrers, models.ReferrerStat{Referrer: host, Views: count})
+		}
+	}
+	sortReferrers(referrers)
+	if len(referrers) > maxDailyReferrers {
+		referrers = referrers[:maxDailyReferrers]
+	}
+
+	top := make(map[string]int, len(referrers))
+	for _, referrer := range referrers {
+		top[referrer.Referrer] = referrer.Views
+	}
+	return top
+}
+
+func sortReferrers(referrers []models.ReferrerStat) {
+	sort.Slice(referrers, func(i, j int) bool {
+		if referrers[i].Views != referrers[j].Views {
+			return referrers[i].Views > referrers[j].Views
+		}
+		return referrers[i].Referrer < referrers[j].Referrer
+	})
+}
+
+type StatsService struct {
+	db *gorm.DB
+}
+
+func NewStatsService() *StatsService {
+	return &StatsService{
+		db: database.GetDB(),
+	}
+}
+
+// StatsQuery is the date range of a stats request. Both ends are inclusive; the default is the last 30 days.
+type StatsQuery struct {
+	From *time.Time `form:"from" time_format:"2006-01-02"`
+	To   *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// dateRange resolves the query to the first and last date column values
+func (q *StatsQuery) dateRange() (time.Time, time.Time, error) {
+	to := statsDate(time.Now())
+	if q.To != nil {
+		to = statsDate(*q.To)
+	}
+	from := to.AddDate(0, 0, -29)
+	if q.From != nil {
+		from = statsDate(*q.From)
+	}
+
+	if from.After(to) {
+		return from, to, errors.ErrInvalidInput("from은 to보다 늦을 수 없습니다")
+	}
+	if to.Sub(from) >= maxStatsDays*24*time.Hour {
+		return from, to, errors.ErrInvalidInput(fmt.Sprintf("한 번에 최대 %d일까지 조회할 수 있습니다", maxStatsDays))
+	}
+	return from, to, nil
+}
+
+// dailySeries lists every day from from to to, filled in from rows keyed by date
+func dailySeries(from, to time.Time, rows map[string]models.DailyStat) []models.DailyStat {
+	days := make([]models.DailyStat, 0, int(to.Sub(from).Hours()/24)+1)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		stat, ok := rows[date]
+		if !ok {
+			stat = models.DailyStat{Date: date}
+		}
+		days = append(days, stat)
+	}
+	return days
+}
+
+// GetArticleStats returns the daily views, unique visitors and referrers of an article.
+// Only the author and staff can see them. Today's numbers lag by up to the rollup interval.
+func (s *StatsService) GetArticleStats(articleID uint, query *StatsQuery, actor policy.Actor) (*models.ArticleStatsResponse, error) {
+	var article models.Article
+	if err := s.db.First(&article, articleID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrArticleNotFound()
+		}
+		return nil, fmt.Errorf("게시글 조회 실패: %w", err)
+	}
+	if !policy.CanViewArticleStats(actor, &article) {
+		return nil, errors.ErrPermissionDenied()
+	}
+
+	from, to, err := query.dateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []models.ArticleDailyStat
+	if err := s.db.Where("article_id = ? AND date BETWEEN ? AND ?", articleID, from, to).
+		Order("date").Find(&stats).Error; err != nil {
+		return nil, fmt.Errorf("통계 조회 실패: %w", err)
+	}
+
+	response := &models.ArticleStatsResponse{
+		ArticleID: articleID,
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+	}
+	rows := make(map[string]models.DailyStat, len(stats))
+	referrers := make(map[string]int)
+	for _, stat := range stats {
+		date := stat.Date.UTC().Format("2006-01-02")
+		rows[date] = models.DailyStat{Date: date, Views: stat.Views, UniqueVisitors: stat.UniqueVisitors}
+		response.Views += stat.Views
+		response.VisitorDays += stat.UniqueVisitors
+		for host, views := range stat.Referrers {
+			referrers[host] += views
+		}
+	}
+	response.Days = dailySeries(from, to, rows)
+
+	response.Referrers = make([]models.ReferrerStat, 0, len(referrers))
+	for host, views := range referrers {
+		response.Referrers = append(response.Referrers, models.ReferrerStat{Referrer: host, Views: views})
+	}
+	sortReferrers(response.Referrers)
+
+	return response, nil
+}
+
+// GetAuthorStats summarizes the views and comments of every article the actor wrote
+func (s *StatsService) GetAuthorStats(query *StatsQuery, actor policy.Actor) (*models.AuthorStatsResponse, error) {
+	from, to, err := query.dateRange()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.AuthorStatsResponse{
+		From: from.Format("2006-01-02"),
+		To:   to.Format("2006-01-02"),
+	}
+
+	var totals struct {
+		ArticleCount  int64
+		TotalViews    int64
+		TotalComments int64
+	}
+	if err := s.db.Model(&models.Article{}).
+		Select("COUNT(*) AS article_count, COALESCE(SUM(view_count), 0) AS total_views, COALESCE(SUM(comment_count), 0) AS total_comments").
+		Where("author_id = ?", actor.UserID).Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("통계 조회 실패: %w", err)
+	}
+	response.ArticleCount = totals.ArticleCount
+	response.TotalViews = totals.TotalViews
+	response.TotalComments = totals.TotalComments
+
+	authored := s.db.Model(&models.ArticleDailyStat{}).
+		Joins("JOIN articles ON articles.id = article_daily_stats.article_id AND articles.deleted_at IS NULL").
+		Where("articles.author_id = ? AND article_daily_stats.date BETWEEN ? AND ?", actor.UserID, from, to).
+		Session(&gorm.Session{})
+
+	var days []struct {
+		Date           time.Time
+		Views          int
+		UniqueVisitors int
+	}
+	if err := authored.
+		Select("article_daily_stats.date, SUM(article_daily_stats.views) AS views, SUM(article_daily_stats.unique_visitors) AS unique_visitors").
+		Group("article_daily_stats.date").Scan(&days).Error; err != nil {
+		return nil, fmt.Errorf("통계 조회 실패: %w", err)
+	}
+	rows := make(map[string]models.DailyStat, len(days))
+	for _, day := range days {
+		date := day.Date.UTC().Format("2006-01-02")
+		rows[date] = models.DailyStat{Date: date, Views: day.Views, UniqueVisitors: day.UniqueVisitors}
+		response.Views += day.Views
+	}
+	response.Days = dailySeries(from, to, rows)
+
+	var top []struct {
+		ArticleID    uint
+		Title        string
+		Slug         string
+		Views        int
+		ViewCount    int
+		CommentCount int
+	}
+	if err := authored.
+		Select("articles.id AS article_id, articles.title, articles.slug, SUM(article_daily_stats.views) AS views, articles.view_count, articles.comment_count").
+		Group("articles.id").Order("views DESC, articles.id DESC").Limit(5).Scan(&top).Error; err != nil {
+		return nil, fmt.Errorf("통계 조회 실패: %w", err)
+	}
+	response.TopArticles = make([]models.AuthorArticleStat, len(top))
+	for i, article := range top {
+		response.TopArticles[i] = models.AuthorArticleStat{
+			ID:           article.ArticleID,
+			Title:        article.Title,
+			Slug:         article.Slug,
+			Views:        article.Views,
+			ViewCount:    article.ViewCount,
+			CommentCount: article.CommentCount,
+		}
+	}
+
+	return response, nil
+}
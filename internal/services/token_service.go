@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type TokenService struct {
+	db *gorm.DB
+}
+
+func NewTokenService() *TokenService {
+	return &TokenService{
+		db: database.GetDB(),
+	}
+}
+
+// RefreshTokenRequest is the request to rotate a refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// IssueTokens starts a new session for the user and returns an access/refresh token pair
+func (s *TokenService) IssueTokens(user *models.User, client middleware.ClientInfo) (*AuthResponse, error) {
+	sessionID, err := middleware.CreateSession(context.Background(), user.ID, client)
+	if err != nil {
+		return nil, errors.NewAppError(500, "세션 생성에 실패했습니다", err.Error())
+	}
+
+	return s.issueForSession(user, sessionID)
+}
+
+func (s *TokenService) issueForSession(user *models.User, sessionID string) (*AuthResponse, error) {
+	token, err := middleware.GenerateToken(user, sessionID)
+	if err != nil {
+		return nil, errors.NewAppError(500, "토큰 생성에 실패했습니다", err.Error())
+	}
+
+	refreshToken, err := middleware.IssueRefreshToken(context.Background(), user.ID, sessionID)
+	if err != nil {
+		return nil, errors.NewAppError(500, "토큰 생성에 실패했습니다", err.Error())
+	}
+
+	return &AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(middleware.AccessTokenTTL().Seconds()),
+		User:         user,
+	}, nil
+}
+
+// Refresh rotates the refresh token and issues a new access token for the same session
+func (s *TokenService) Refresh(refreshToken string) (*AuthResponse, error) {
+	ctx := context.Background()
+
+	userID, sessionID, err := middleware.ConsumeRefreshToken(ctx, refreshToken)
+	switch err {
+	case nil:
+	case middleware.ErrRefreshTokenInvalid:
+		return nil, errors.ErrInvalidRefreshToken()
+	case middleware.ErrRefreshTokenReused:
+		return nil, errors.ErrRefreshTokenReused()
+	default:
+		return nil, errors.NewAppError(500, "토큰 갱신에 실패했습니다", err.Error())
+	}
+
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			middleware.RevokeSession(ctx, userID, sessionID)
+			return nil, errors.ErrInvalidRefreshToken()
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return s.issueForSession(&user, sessionID)
+}
+
+// Logout revokes the current session
+func (s *TokenService) Logout(userID uint, sessionID string) error {
+	if err := middleware.RevokeSession(context.Background(), userID, sessionID); err != nil {
+		return errors.NewAppError(500, "로그아웃에 실패했습니다", err.Error())
+	}
+	return nil
+}
+
+// ListSessions returns the user's active sessions and marks the one making the request
+func (s *TokenService) ListSessions(userID uint, currentSessionID string) ([]middleware.SessionInfo, error) {
+	sessions, err := middleware.ListSessions(context.Background(), userID)
+	if err != nil {
+		return nil, errors.NewAppError(500, "세션 목록 조회에 실패했습니다", err.Error())
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession logs out one of the user's sessions, e.g. a lost device
+func (s *TokenService) RevokeSession(userID uint, sessionID string) error {
+	ctx := context.Background()
+
+	active, err := middleware.IsSessionActive(ctx, userID, sessionID)
+	if err != nil {
+		return errors.NewAppError(500, "세션 조회에 실패했습니다", err.Error())
+	}
+	if !active {
+		return errors.ErrSessionNotFound()
+	}
+
+	if err := middleware.RevokeSession(ctx, userID, sessionID); err != nil {
+		return errors.NewAppError(500, "세션 종료에 실패했습니다", err.Error())
+	}
+	return nil
+}
+
+// LogoutAll revokes every session of the user
+func (s *TokenService) LogoutAll(userID uint) error {
+	if err := middleware.RevokeAllSessions(context.Background(), userID); err != nil {
+		return errors.NewAppError(500, "로그아웃에 실패했습니다", err.Error())
+	}
+	return nil
+}
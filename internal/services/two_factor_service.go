@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// 등록을 시작한 뒤 첫 코드를 확인하기까지 허용하는 시간
+	totpSetupTTL = 10 * time.Minute
+	// 비밀번호 확인 후 2단계 인증을 마치기까지 허용하는 시간
+	twoFactorChallengeTTL = 5 * time.Minute
+	maxTwoFactorAttempts  = 5
+	// 챌린지는 로그인할 때마다 새로 발급되므로 사용자별로도 실패를 셉니다.
+	// twoFactorFailureWindow 안에 maxTwoFactorFailures번 틀리면 twoFactorLockout 동안 2단계 인증을 막습니다
+	maxTwoFactorFailures    = 10
+	twoFactorFailureWindow  = time.Hour
+	twoFactorLockout        = 30 * time.Minute
+	recoveryCodeCount       = 10
+	totpReplayProtectionTTL = 2 * time.Minute
+)
+
+type TwoFactorService struct {
+	db     *gorm.DB
+	tokens *TokenService
+	guard  *loginGuard
+	issuer string
+}
+
+func NewTwoFactorService() *TwoFactorService {
+	cfg := config.LoadConfig()
+	db := database.GetDB()
+	return &TwoFactorService{
+		db:     db,
+		tokens: NewTokenService(),
+		guard:  newLoginGuard(db, cfg.Login),
+		issuer: cfg.TwoFactor.Issuer,
+	}
+}
+
+// TwoFactorSetupResponse carries the secret to register in an authenticator app
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	ExpiresIn  int    `json:"expires_in"`
+}
+
+// ConfirmTwoFactorRequest is the request to finish enrollment with the first TOTP code
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code" binding:"required,len=6"`
+}
+
+// RecoveryCodesResponse lists recovery codes. They are only shown once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// DisableTwoFactorRequest is the request to turn off two-factor authentication
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	// TOTP 코드 또는 복구 코드
+	Code string `json:"code" binding:"required"`
+}
+
+// TwoFactorLoginRequest exchanges a login challenge and a second factor for tokens
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+func totpSetupKey(userID uint) string {
+	return fmt.Sprintf("totp_setup:%d", userID)
+}
+
+// twoFactorChallenge is kept in Redis until the second factor is verified
+type twoFactorChallenge struct {
+	UserID uint                  `json:"user_id"`
+	Client middleware.ClientInfo `json:"client"`
+}
+
+func twoFactorChallengeKey(token string) string {
+	return fmt.Sprintf("2fa_challenge:%s", utils.HashToken(token))
+}
+
+func twoFactorFailuresKey(userID uint) string {
+	return fmt.Sprintf("2fa_failures:%d", userID)
+}
+
+func twoFactorLockKey(userID uint) string {
+	return fmt.Sprintf("2fa_lock:%d", userID)
+}
+
+// checkTwoFactorLock returns ErrAccountLocked while the user's second factor is locked
+func checkTwoFactorLock(ctx context.Context, userID uint) error {
+	if ttl, err := database.GetRedis().TTL(ctx, twoFactorLockKey(userID)).Result(); err == nil && ttl > 0 {
+		return errors.ErrAccountLocked(int(ttl.Round(time.Second).Seconds()))
+	}
+	return nil
+}
+
+func (s *TwoFactorService) getUser(userID uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFound()
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+// Setup starts enrollment. The secret is kept aside until Confirm proves the app was set up.
+func (s *TwoFactorService) Setup(userID uint) (*TwoFactorSetupResponse, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, errors.ErrTwoFactorAlreadyEnabled()
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, errors.NewAppError(500, "2단계 인증 키 생성에 실패했습니다", err.Error())
+	}
+
+	if err := database.GetRedis().Set(context.Background(), totpSetupKey(userID), secret, totpSetupTTL).Err(); err != nil {
+		return nil, errors.NewAppError(500, "2단계 인증 키 저장에 실패했습니다", err.Error())
+	}
+
+	return &TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(s.issuer, user.Email, secret),
+		ExpiresIn:  int(totpSetupTTL.Seconds()),
+	}, nil
+}
+
+// Confirm enables two-factor authentication and returns a fresh set of recovery codes
+func (s *TwoFactorService) Confirm(userID uint, code string) (*RecoveryCodesResponse, error) {
+	ctx := context.Background()
+
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, errors.ErrTwoFactorAlreadyEnabled()
+	}
+
+	secret, err := database.GetRedis().Get(ctx, totpSetupKey(userID)).Result()
+	if err != nil {
+		return nil, errors.ErrTwoFactorSetupExpired()
+	}
+
+	if _, ok := utils.ValidateTOTP(secret, code, time.Now()); !ok {
+		return nil, errors.ErrInvalidTwoFactorCode()
+	}
+
+	var codes []string
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(user).Updates(map[string]interface{}{
+			"totp_secret":        secret,
+			"two_factor_enabled": true,
+		}).Error; err != nil {
+			return err
+		}
+
+		codes, err = replaceRecoveryCodes(tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, errors.NewAppError(500, "2단계 인증 활성화에 실패했습니다", err.Error())
+	}
+
+	database.GetRedis().Del(ctx, totpSetupKey(userID))
+
+	return &RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns off two-factor authentication after checking the password and a second factor
+func (s *TwoFactorService) Disable(userID uint, req *DisableTwoFactorRequest) error {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return errors.ErrTwoFactorNotEnabled()
+	}
+
+	if err := utils.CheckPassword(user.Password, req.Password); err != nil {
+		return errors.ErrIncorrectPassword()
+	}
+
+	if !s.verifyTOTP(user, req.Code) && !s.useRecoveryCode(user.ID, req.Code) {
+		return errors.ErrInvalidTwoFactorCode()
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(user).Updates(map[string]interface{}{
+			"totp_secret":        "",
+			"two_factor_enabled": false,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", user.ID).Delete(&models.RecoveryCode{}).Error
+	})
+	if err != nil {
+		return errors.NewAppError(500, "2단계 인증 해제에 실패했습니다", err.Error())
+	}
+
+	return nil
+}
+
+// StartLogin finishes a successful first factor of any login method (password, magic link, social login).
+// Users without two-factor authentication are logged in and the login is recorded as successful here;
+// users with it get a short-lived challenge token instead, and CompleteLogin records the success.
+func (s *TwoFactorService) StartLogin(user *models.User, client middleware.ClientInfo) (*AuthResponse, error) {
+	if !user.TwoFactorEnabled {
+		s.guard.succeed(context.Background(), user, client)
+		return s.tokens.IssueTokens(user, client)
+	}
+
+	// 잠긴 동안에는 새 챌린지를 발급하지 않습니다
+	if err := checkTwoFactorLock(context.Background(), user.ID); err != nil {
+		s.guard.record(user, normalizeLoginEmail(user.Email), client, models.LoginResultLocked)
+		return nil, err
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, errors.NewAppError(500, "인증 토큰 생성에 실패했습니다", err.Error())
+	}
+
+	challenge, err := json.Marshal(twoFactorChallenge{UserID: user.ID, Client: client})
+	if err != nil {
+		return nil, errors.NewAppError(500, "인증 토큰 저장에 실패했습니다", err.Error())
+	}
+	if err := database.GetRedis().Set(context.Background(), twoFactorChallengeKey(token), challenge, twoFactorChallengeTTL).Err(); err != nil {
+		return nil, errors.NewAppError(500, "인증 토큰 저장에 실패했습니다", err.Error())
+	}
+
+	return &AuthResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresIn:         int(twoFactorChallengeTTL.Seconds()),
+	}, nil
+}
+
+// CompleteLogin exchanges a challenge token and a TOTP or recovery code for a session.
+// Wrong codes count against the challenge, the user and the client's IP, see failSecondFactor.
+func (s *TwoFactorService) CompleteLogin(req *TwoFactorLoginRequest, client middleware.ClientInfo) (*AuthResponse, error) {
+	ctx := context.Background()
+	rdb := database.GetRedis()
+	challengeKey := twoFactorChallengeKey(req.ChallengeToken)
+
+	if err := s.guard.checkIP(ctx, client.IP); err != nil {
+		return nil, err
+	}
+
+	value, err := rdb.Get(ctx, challengeKey).Result()
+	if err != nil {
+		return nil, errors.ErrInvalidTwoFactorChallenge()
+	}
+
+	var challenge twoFactorChallenge
+	if err := json.Unmarshal([]byte(value), &challenge); err != nil {
+		return nil, errors.ErrInvalidTwoFactorChallenge()
+	}
+
+	user, err := s.getUser(challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTwoFactorLock(ctx, user.ID); err != nil {
+		rdb.Del(ctx, challengeKey, challengeKey+":attempts")
+		return nil, err
+	}
+	// 2단계 인증 실패도 비밀번호 실패와 같이 이메일별 지연과 잠금에 포함됩니다
+	if err := s.guard.check(ctx, user.Email, client.IP); err != nil {
+		s.guard.blocked(user.Email, client, err)
+		return nil, err
+	}
+
+	verified := false
+	if req.Code != "" {
+		verified = s.verifyTOTP(user, req.Code)
+	} else if req.RecoveryCode != "" {
+		verified = s.useRecoveryCode(user.ID, req.RecoveryCode)
+	}
+
+	if !verified {
+		return nil, s.failSecondFactor(ctx, user, challengeKey, client)
+	}
+
+	// 같은 챌린지로 두 번 로그인하지 못하도록 삭제에 성공한 요청만 진행합니다
+	deleted, err := rdb.Del(ctx, challengeKey, challengeKey+":attempts").Result()
+	if err != nil || deleted == 0 {
+		return nil, errors.ErrInvalidTwoFactorChallenge()
+	}
+	rdb.Del(ctx, twoFactorFailuresKey(user.ID))
+	s.guard.succeed(ctx, user, challenge.Client)
+
+	return s.tokens.IssueTokens(user, challenge.Client)
+}
+
+// failSecondFactor records a wrong code and returns the error for the response. The challenge is dropped
+// after maxTwoFactorAttempts; the user's second factor is locked after maxTwoFactorFailures across challenges.
+func (s *TwoFactorService) failSecondFactor(ctx context.Context, user *models.User, challengeKey string, client middleware.ClientInfo) error {
+	rdb := database.GetRedis()
+
+	s.guard.fail(ctx, user, user.Email, client, models.LoginResultInvalidTwoFactor)
+
+	failures, err := hitRateLimit(ctx, twoFactorFailuresKey(user.ID), twoFactorFailureWindow)
+	if err != nil {
+		return errors.NewAppError(500, "인증 시도 기록에 실패했습니다", err.Error())
+	}
+	if failures.Count >= maxTwoFactorFailures {
+		rdb.Set(ctx, twoFactorLockKey(user.ID), 1, twoFactorLockout)
+		rdb.Del(ctx, challengeKey, challengeKey+":attempts", twoFactorFailuresKey(user.ID))
+		return errors.ErrAccountLocked(int(twoFactorLockout.Seconds()))
+	}
+
+	attempts, err := hitRateLimit(ctx, challengeKey+":attempts", twoFactorChallengeTTL)
+	if err != nil {
+		return errors.NewAppError(500, "인증 시도 기록에 실패했습니다", err.Error())
+	}
+	if attempts.Count >= maxTwoFactorAttempts {
+		rdb.Del(ctx, challengeKey, challengeKey+":attempts")
+		return errors.ErrInvalidTwoFactorChallenge()
+	}
+	return errors.ErrInvalidTwoFactorCode()
+}
+
+// verifyTOTP validates a TOTP code and rejects codes that were already used
+func (s *TwoFactorService) verifyTOTP(user *models.User, code string) bool {
+	if !user.TwoFactorEnabled || user.TOTPSecret == "" {
+		return false
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return false
+	}
+
+	fresh, err := database.GetRedis().SetNX(context.Background(), fmt.Sprintf("totp_used:%d:%d", user.ID, step), 1, totpReplayProtectionTTL).Result()
+	return err == nil && fresh
+}
+
+// useRecoveryCode marks a matching unused recovery code as used
+func (s *TwoFactorService) useRecoveryCode(userID uint, code string) bool {
+	codeHash := utils.HashToken(utils.NormalizeRecoveryCode(code))
+	result := s.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.Error == nil && result.RowsAffected > 0
+}
+
+// replaceRecoveryCodes deletes the user's recovery codes and stores a new set
+func replaceRecoveryCodes(tx *gorm.DB, userID uint) ([]string, error) {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	records := make([]models.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		code, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		records[i] = models.RecoveryCode{UserID: userID, CodeHash: utils.HashToken(code)}
+	}
+
+	if err := tx.Create(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
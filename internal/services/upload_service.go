@@ -2,11 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"path/filepath"
 	"portfolio-server/internal/config"
 	"portfolio-server/internal/database"
+	"portfolio-server/internal/policy"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -30,6 +34,14 @@ func NewUploadService() *UploadService {
 	}
 }
 
+var (
+	ErrUploadNotFound  = errors.New("uploaded file not found")
+	ErrUploadForbidden = errors.New("not allowed to delete this file")
+)
+
+// 업로드한 사용자 ID를 저장하는 오브젝트 메타데이터 키
+const uploaderMetadataKey = "Uploader-Id"
+
 type UploadResponse struct {
 	URL      string `json:"url"`
 	FileName string `json:"fileName"`
@@ -37,7 +49,7 @@ type UploadResponse struct {
 }
 
 // UploadImage 이미지를 MinIO에 업로드하고 URL 반환
-func (s *UploadService) UploadImage(ctx context.Context, file *multipart.FileHeader) (*UploadResponse, error) {
+func (s *UploadService) UploadImage(ctx context.Context, file *multipart.FileHeader, uploaderID uint) (*UploadResponse, error) {
 	// 파일 열기
 	src, err := file.Open()
 	if err != nil {
@@ -61,6 +73,9 @@ func (s *UploadService) UploadImage(ctx context.Context, file *multipart.FileHea
 	// MinIO에 업로드
 	_, err = s.minioClient.PutObject(ctx, s.bucket, objectName, src, file.Size, minio.PutObjectOptions{
 		ContentType: contentType,
+		UserMetadata: map[string]string{
+			uploaderMetadataKey: strconv.FormatUint(uint64(uploaderID), 10),
+		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload to MinIO: %w", err)
@@ -80,11 +95,28 @@ func (s *UploadService) UploadImage(ctx context.Context, file *multipart.FileHea
 	}, nil
 }
 
-// DeleteImage MinIO에서 이미지 삭제
-func (s *UploadService) DeleteImage(ctx context.Context, fileName string) error {
+// DeleteImage MinIO에서 이미지 삭제 (업로드한 사용자 또는 관리자만 가능)
+func (s *UploadService) DeleteImage(ctx context.Context, fileName string, actor policy.Actor) error {
+	// images/ 밖의 오브젝트를 지정하지 못하도록 경로 구분자를 허용하지 않습니다
+	if fileName != filepath.Base(fileName) || strings.Contains(fileName, "..") {
+		return ErrUploadNotFound
+	}
 	objectName := fmt.Sprintf("images/%s", fileName)
-	
-	err := s.minioClient.RemoveObject(ctx, s.bucket, objectName, minio.RemoveObjectOptions{})
+
+	info, err := s.minioClient.StatObject(ctx, s.bucket, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return ErrUploadNotFound
+		}
+		return fmt.Errorf("failed to stat object in MinIO: %w", err)
+	}
+
+	ownerID, _ := strconv.ParseUint(info.UserMetadata[uploaderMetadataKey], 10, 32)
+	if !policy.CanDeleteUpload(actor, uint(ownerID)) {
+		return ErrUploadForbidden
+	}
+
+	err = s.minioClient.RemoveObject(ctx, s.bucket, objectName, minio.RemoveObjectOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to delete from MinIO: %w", err)
 	}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/errors"
+	"portfolio-server/internal/utils"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 인증 코드 유효 시간 (메일 본문에 안내된 시간과 같아야 합니다)
+const verificationCodeTTL = 10 * time.Minute
+
+// consumeVerificationCode deletes the code (KEYS[1]) and its attempt counter (KEYS[2]) only if the code
+// is still ARGV[1], and returns 1 when it did. Of two requests with the same code only one gets 1.
+var consumeVerificationCode = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("DEL", KEYS[1], KEYS[2])
+	return 1
+end
+return 0
+`)
+
+// verificationCodes stores 6-digit codes in Redis and guards them against brute force and inbox spamming.
+//
+// 코드는 <namespace>:<subject> 에 저장되고, 실패 횟수는 <namespace>:attempts:<subject> 에 저장됩니다.
+// 재전송 쿨다운과 일일 발송 한도는 수신 이메일 기준, IP 제한은 IP 기준으로 모든 용도가 공유합니다.
+type verificationCodes struct {
+	namespace string
+	cfg       config.VerificationConfig
+}
+
+func newVerificationCodes(namespace string, cfg config.VerificationConfig) *verificationCodes {
+	return &verificationCodes{namespace: namespace, cfg: cfg}
+}
+
+func (v *verificationCodes) codeKey(subject string) string {
+	return fmt.Sprintf("%s:%s", v.namespace, subject)
+}
+
+func (v *verificationCodes) attemptsKey(subject string) string {
+	return fmt.Sprintf("%s:attempts:%s", v.namespace, subject)
+}
+
+// Send generates a new code for subject and delivers it to email with deliver.
+// A new code replaces the previous one and resets its failed attempt counter.
+func (v *verificationCodes) Send(subject, email, clientIP string, deliver func(code string) error) error {
+	ctx := context.Background()
+
+	ipLimit, err := hitRateLimit(ctx, fmt.Sprintf("verification:ip:send:%s", clientIP), time.Hour)
+	if err != nil {
+		return errors.NewAppError(500, "요청 제한 확인에 실패했습니다", err.Error())
+	}
+	if ipLimit.Count > int64(v.cfg.IPSendLimitPerHour) {
+		return errors.ErrTooManyRequests(ipLimit.RetryAfter)
+	}
+
+	ok, retryAfter, err := acquireCooldown(ctx, fmt.Sprintf("verification:cooldown:%s", email), time.Duration(v.cfg.ResendCooldownSeconds)*time.Second)
+	if err != nil {
+		return errors.NewAppError(500, "요청 제한 확인에 실패했습니다", err.Error())
+	}
+	if !ok {
+		return errors.ErrVerificationCooldown(retryAfter)
+	}
+
+	daily, err := hitRateLimit(ctx, fmt.Sprintf("verification:daily:%s", email), 24*time.Hour)
+	if err != nil {
+		return errors.NewAppError(500, "요청 제한 확인에 실패했습니다", err.Error())
+	}
+	if daily.Count > int64(v.cfg.DailySendLimit) {
+		return errors.ErrVerificationDailyLimit(daily.RetryAfter)
+	}
+
+	// 6자리 인증 코드 생성
+	code, err := utils.GenerateVerificationCode()
+	if err != nil {
+		return errors.NewAppError(500, "인증 코드 생성에 실패했습니다", err.Error())
+	}
+
+	pipe := database.GetRedis().TxPipeline()
+	pipe.Set(ctx, v.codeKey(subject), code, verificationCodeTTL)
+	pipe.Del(ctx, v.attemptsKey(subject))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.NewAppError(500, "인증 코드 저장에 실패했습니다", err.Error())
+	}
+
+	if err := deliver(code); err != nil {
+		return errors.NewAppError(500, "이메일 전송에 실패했습니다", err.Error())
+	}
+
+	return nil
+}
+
+// Verify checks code for subject and deletes it on success. The code is consumed atomically, so
+// when the same code is sent twice at the same time only one request succeeds.
+// After MaxAttempts wrong guesses the code is invalidated and a new one must be requested.
+func (v *verificationCodes) Verify(subject, code, clientIP string) error {
+	ctx := context.Background()
+	rdb := database.GetRedis()
+
+	ipLimit, err := hitRateLimit(ctx, fmt.Sprintf("verification:ip:verify:%s", clientIP), time.Hour)
+	if err != nil {
+		return errors.NewAppError(500, "요청 제한 확인에 실패했습니다", err.Error())
+	}
+	if ipLimit.Count > int64(v.cfg.IPVerifyLimitPerHour) {
+		return errors.ErrTooManyRequests(ipLimit.RetryAfter)
+	}
+
+	savedCode, err := rdb.Get(ctx, v.codeKey(subject)).Result()
+	if err == redis.Nil {
+		return errors.ErrVerificationCodeExpired()
+	}
+	if err != nil {
+		return errors.NewAppError(500, "인증 코드 조회에 실패했습니다", err.Error())
+	}
+
+	if subtle.ConstantTimeCompare([]byte(savedCode), []byte(code)) != 1 {
+		attempts, err := hitRateLimit(ctx, v.attemptsKey(subject), verificationCodeTTL)
+		if err != nil {
+			return errors.NewAppError(500, "인증 시도 기록에 실패했습니다", err.Error())
+		}
+		if attempts.Count >= int64(v.cfg.MaxAttempts) {
+			rdb.Del(ctx, v.codeKey(subject), v.attemptsKey(subject))
+			return errors.ErrVerificationCodeInvalidated()
+		}
+		return errors.ErrInvalidVerificationCode(v.cfg.MaxAttempts - int(attempts.Count))
+	}
+
+	// 인증 성공 시 Redis에서 삭제합니다. 지우지 못했다면 다른 요청이 먼저 사용한 코드입니다
+	consumed, err := consumeVerificationCode.Run(ctx, rdb, []string{v.codeKey(subject), v.attemptsKey(subject)}, savedCode).Int()
+	if err != nil {
+		return errors.NewAppError(500, "인증 코드 처리에 실패했습니다", err.Error())
+	}
+	if consumed == 0 {
+		return errors.ErrVerificationCodeExpired()
+	}
+
+	return nil
+}
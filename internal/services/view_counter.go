@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"portfolio-server/internal/config"
+	"portfolio-server/internal/database"
+	"portfolio-server/internal/middleware"
+	"portfolio-server/internal/models"
+	"portfolio-server/internal/policy"
+	"portfolio-server/internal/utils"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// 조회수는 Redis에 모아 두었다가 ViewFlusher가 주기적으로 DB에 반영합니다.
+//
+//	article_view:<article_id>:<viewer>  같은 사람의 중복 조회 방지 (DedupeMinutes 동안 유지)
+//	article_views:pending               글 ID별로 아직 DB에 반영되지 않은 조회수 (hash)
+//	article_views:ranking               글 ID별 누적 조회수 (sorted set, DB에 반영할 때 갱신)
+//	article_views:flushing:<unix>:<id>  반영 중인 조회수. 반영이 끝나면 삭제됩니다
+//	article_views:flushing              반영 중인 키 목록 (set). 서버가 중간에 종료되어 남은 키를 되살리는 데 씁니다
+const (
+	pendingViewsKey  = "article_views:pending"
+	viewRankingKey   = "article_views:ranking"
+	flushingViewsKey = "article_views:flushing"
+)
+
+const (
+	// 한 번에 DB에 반영하는 글 수
+	viewFlushBatchSize = 500
+	// 이보다 오래된 반영 중인 키는 반영하던 서버가 종료된 것으로 보고 pending으로 되돌립니다
+	staleFlushAfter = 10 * time.Minute
+)
+
+// recoverFlushingViews adds an abandoned flushing hash back to the pending hash and forgets it, atomically
+// so that two servers recovering the same key do not count its views twice
+var recoverFlushingViews = redis.NewScript(`
+local values = redis.call('HGETALL', KEYS[1])
+for i = 1, #values, 2 do
+	redis.call('HINCRBY', KEYS[2], values[i], values[i + 1])
+end
+redis.call('DEL', KEYS[1])
+redis.call('SREM', KEYS[3], KEYS[1])
+return #values / 2
+`)
+
+// removeFromRankings takes an article that was deleted or is no longer public out of the view ranking
+// and the computed rankings. Daily activity is kept; publicRankedArticles skips the article until it is public again.
+func removeFromRankings(ctx context.Context, articleID uint) {
+	member := strconv.FormatUint(uint64(articleID), 10)
+	pipe := database.GetRedis().Pipeline()
+	pipe.ZRem(ctx, viewRankingKey, member)
+	pipe.ZRem(ctx, trendingKey, member)
+	for period := range leaderboardDays {
+		pipe.ZRem(ctx, leaderboardKey(period), member)
+	}
+	pipe.ZRem(ctx, leaderboardKey("all"), member)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to remove article from rankings: %v", err)
+	}
+}
+
+// syncViewRanking updates the view ranking after an article's status may have changed
+func syncViewRanking(ctx context.Context, article *models.Article) {
+	if !article.IsPublic(time.Now()) {
+		removeFromRankings(ctx, article.ID)
+		return
+	}
+	if article.ViewCount > 0 {
+		member := strconv.FormatUint(uint64(article.ID), 10)
+		if err := database.GetRedis().ZAdd(ctx, viewRankingKey, redis.Z{Score: float64(article.ViewCount), Member: member}).Err(); err != nil {
+			log.Printf("Failed to update view ranking: %v", err)
+		}
+	}
+}
+
+// 검색 엔진, 링크 미리보기, 모니터링 도구의 요청은 조회수에 넣지 않습니다
+var botUserAgent = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|curl|wget|python-requests|headless|preview|monitor`)
+
+type viewCounter struct {
+	dedupe time.Duration
+	// 프런트엔드 호스트. 여기서 넘어온 조회는 유입 경로를 "internal"로 기록합니다
+	ownHost string
+}
+
+func newViewCounter(cfg config.ViewConfig, frontendURL string) *viewCounter {
+	dedupe := time.Duration(cfg.DedupeMinutes) * time.Minute
+	if dedupe <= 0 {
+		dedupe = 30 * time.Minute
+	}
+	return &viewCounter{
+		dedupe:  dedupe,
+		ownHost: referrerHost(frontendURL, ""),
+	}
+}
+
+// viewerKey identifies a viewer: the user when logged in, otherwise a hash of the IP and user agent
+func viewerKey(viewer policy.Actor, client middleware.ClientInfo) string {
+	if viewer.UserID != 0 {
+		return fmt.Sprintf("u%d", viewer.UserID)
+	}
+	return "a" + utils.HashToken(client.IP + "|" + client.UserAgent)[:32]
+}
+
+// count records a view of the article unless the same viewer saw it within the dedupe window.
+// Views by the author and by bots are ignored. It returns the article's views that have not been
+// flushed to the database yet, so the caller can show an up-to-date count.
+func (v *viewCounter) count(ctx context.Context, article *models.Article, viewer policy.Actor, client middleware.ClientInfo) int64 {
+	rdb := database.GetRedis()
+	member := strconv.FormatUint(uint64(article.ID), 10)
+
+	counted := viewer.UserID != article.AuthorID &&
+		(viewer.UserID != 0 || (client.UserAgent != "" && !botUserAgent.MatchString(client.UserAgent)))
+	if counted {
+		visitor := viewerKey(viewer, client)
+		key := fmt.Sprintf("article_view:%d:%s", article.ID, visitor)
+		first, err := rdb.SetNX(ctx, key, 1, v.dedupe).Result()
+		if err != nil {
+			log.Printf("Failed to count article view: %v", err)
+			return 0
+		}
+		now := time.Now()
+		recordVisit(ctx, article.ID, visitor, referrerHost(client.Referrer, v.ownHost), first, now)
+		if first {
+			recordDailyActivity(ctx, dailyViewsKeyPrefix, article.ID, now)
+			pending, err := rdb.HIncrBy(ctx, pendingViewsKey, member, 1).Result()
+			if err != nil {
+				log.Printf("Failed to count article view: %v", err)
+				return 0
+			}
+			return pending
+		}
+	}
+
+	pending, err := rdb.HGet(ctx, pendingViewsKey, member).Int64()
+	if err != nil && err != redis.Nil {
+		log.Printf("Failed to read pending article views: %v", err)
+	}
+	return pending
+}
+
+// ViewFlusher periodically adds the views buffered in Redis to articles.view_count and
+// refreshes the view ranking. A single UPDATE per batch replaces one UPDATE per page view.
+type ViewFlusher struct {
+	db       *gorm.DB
+	interval time.Duration
+}
+
+func NewViewFlusher() *ViewFlusher {
+	cfg := config.LoadConfig()
+	interval := time.Duration(cfg.View.FlushIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return &ViewFlusher{
+		db:       database.GetDB(),
+		interval: interval,
+	}
+}
+
+// Start seeds the view ranking when Redis lost it and flushes in the background until ctx is cancelled.
+// Views left behind by a server that stopped in the middle of a flush are recovered on every run.
+func (f *ViewFlusher) Start(ctx context.Context) {
+	if err := f.SeedRanking(ctx); err != nil {
+		log.Printf("Failed to seed view ranking: %v", err)
+	}
+
+	runEvery(ctx, f.interval, func(ctx context.Context) {
+		if err := f.RecoverStale(ctx, time.Now()); err != nil {
+			log.Printf("Failed to recover article views: %v", err)
+		}
+		if count, err := f.Flush(ctx); err != nil {
+			log.Printf("Failed to flush article views: %v", err)
+		} else if count > 0 {
+			log.Printf("Flushed views of %d article(s)", count)
+		}
+	})
+}
+
+// SeedRanking fills the view ranking from the database if it does not exist
+func (f *ViewFlusher) SeedRanking(ctx context.Context) error {
+	rdb := database.GetRedis()
+	exists, err := rdb.Exists(ctx, viewRankingKey).Result()
+	if err != nil || exists > 0 {
+		return err
+	}
+
+	var articles []models.Article
+	err = f.db.Select("id", "view_count").Scopes(publicArticles(time.Now())).
+		Where("view_count > 0").
+		FindInBatches(&articles, viewFlushBatchSize, func(tx *gorm.DB, batch int) error {
+			members := make([]redis.Z, len(articles))
+			for i, article := range articles {
+				members[i] = redis.Z{Score: float64(article.ViewCount), Member: strconv.FormatUint(uint64(article.ID), 10)}
+			}
+			return rdb.ZAdd(ctx, viewRankingKey, members...).Err()
+		}).Error
+	return err
+}
+
+// RecoverStale adds the views of flushes that did not finish within staleFlushAfter back to the pending hash
+func (f *ViewFlusher) RecoverStale(ctx context.Context, now time.Time) error {
+	rdb := database.GetRedis()
+	keys, err := rdb.SMembers(ctx, flushingViewsKey).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		parts := strings.Split(key, ":")
+		if len(parts) != 4 {
+			rdb.SRem(ctx, flushingViewsKey, key)
+			continue
+		}
+		startedAt, err := strconv.ParseInt(parts[2], 10, 64)
+		if err == nil && now.Sub(time.Unix(startedAt, 0)) < staleFlushAfter {
+			continue
+		}
+
+		count, err := recoverFlushingViews.Run(ctx, rdb, []string{key, pendingViewsKey, flushingViewsKey}).Int()
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			log.Printf("Recovered unflushed views of %d article(s)", count)
+		}
+	}
+	return nil
+}
+
+// Flush moves the buffered views to the database and returns the number of articles updated.
+// The pending hash is renamed first so views counted during the flush go into a new hash;
+// if the database update fails the views are added back. The renamed hash is registered in
+// flushingViewsKey before the rename, so RecoverStale can find it if this server stops mid-flush.
+func (f *ViewFlusher) Flush(ctx context.Context) (int, error) {
+	rdb := database.GetRedis()
+	flushing := fmt.Sprintf("article_views:flushing:%d:%s", time.Now().Unix(), uuid.NewString())
+
+	if err := rdb.SAdd(ctx, flushingViewsKey, flushing).Err(); err != nil {
+		return 0, err
+	}
+	defer rdb.SRem(ctx, flushingViewsKey, flushing)
+
+	if err := rdb.Rename(ctx, pendingViewsKey, flushing).Err(); err != nil {
+		if strings.Contains(err.Error(), "no such key") {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	values, err := rdb.HGetAll(ctx, flushing).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+
+	flushed := 0
+	for start := 0; start < len(ids); start += viewFlushBatchSize {
+		end := start + viewFlushBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		count, err := f.flushBatch(ctx, batch, values)
+		if err != nil {
+			f.restore(ctx, ids[start:], values)
+			rdb.Del(ctx, flushing)
+			return flushed, err
+		}
+		flushed += count
+	}
+
+	return flushed, rdb.Del(ctx, flushing).Err()
+}
+
+func (f *ViewFlusher) flushBatch(ctx context.Context, ids []string, values map[string]string) (int, error) {
+	rows := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids)*2)
+	for _, id := range ids {
+		articleID, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			continue
+		}
+		views, err := strconv.ParseInt(values[id], 10, 64)
+		if err != nil || views <= 0 {
+			continue
+		}
+		rows = append(rows, "(?::bigint, ?::bigint)")
+		args = append(args, articleID, views)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	var articles []models.Article
+	err := f.db.Raw(`UPDATE articles SET view_count = articles.view_count + v.views
+		FROM (VALUES `+strings.Join(rows, ", ")+`) AS v(id, views)
+		WHERE articles.id = v.id AND articles.deleted_at IS NULL
+		RETURNING articles.id, articles.view_count, articles.status, articles.published_at`, args...).
+		Scan(&articles).Error
+	if err != nil {
+		return 0, err
+	}
+
+	// 랭킹에는 DB에 반영된 누적 조회수를 그대로 기록합니다
+	now := time.Now()
+	pipe := database.GetRedis().Pipeline()
+	for i := range articles {
+		member := strconv.FormatUint(uint64(articles[i].ID), 10)
+		if articles[i].IsPublic(now) {
+			pipe.ZAdd(ctx, viewRankingKey, redis.Z{Score: float64(articles[i].ViewCount), Member: member})
+		} else {
+			pipe.ZRem(ctx, viewRankingKey, member)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to update view ranking: %v", err)
+	}
+
+	return len(articles), nil
+}
+
+// restore puts views that could not be flushed back into the pending hash
+func (f *ViewFlusher) restore(ctx context.Context, ids []string, values map[string]string) {
+	pipe := database.GetRedis().Pipeline()
+	for _, id := range ids {
+		if views, err := strconv.ParseInt(values[id], 10, 64); err == nil && views > 0 {
+			pipe.HIncrBy(ctx, pendingViewsKey, id, views)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to restore pending article views: %v", err)
+	}
+}
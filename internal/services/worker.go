@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// workers tracks the loops started by runEvery so shutdown can wait for them
+var workers sync.WaitGroup
+
+// runEvery runs fn in the background right away and then every interval until ctx is cancelled.
+// Cancelling ctx only stops further runs: fn gets a context that is not cancelled with it, so a run
+// in progress (a view flush, for example) is not cut off halfway.
+//
+// 백그라운드 작업은 모든 서버 인스턴스에서 잠금 없이 동시에 실행됩니다. 그래서 fn은 여러 번,
+// 여러 곳에서 동시에 실행되어도 결과가 같아야 합니다 (같은 UPDATE, 같은 값으로 덮어쓰기 등).
+func runEvery(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			fn(context.WithoutCancel(ctx))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// WaitForWorkers blocks until every background worker has stopped after its context was cancelled
+func WaitForWorkers() {
+	workers.Wait()
+}
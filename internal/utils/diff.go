@@ -0,0 +1,163 @@
+package utils
+
+import "strings"
+
+// 편집 거리가 이보다 크면 최소 diff를 찾지 않고 남은 부분 전체를 삭제 후 추가한 것으로 표시합니다.
+// Myers 알고리즘의 메모리 사용량이 편집 거리의 제곱에 비례하기 때문입니다.
+const maxDiffEdits = 2000
+
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// DiffLine is one line of a line-based diff.
+// OldLine and NewLine are 1-based line numbers, 0 when the line does not exist on that side.
+type DiffLine struct {
+	Op      string
+	OldLine int
+	NewLine int
+	Text    string
+}
+
+// SplitLines splits text into lines, treating "\r\n" like "\n"
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// DiffLines returns the shortest line-based edit script turning a into b (Myers' algorithm)
+func DiffLines(a, b []string) []DiffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]DiffLine, 0, len(a)+len(b)-prefix-suffix)
+	for i := 0; i < prefix; i++ {
+		lines = append(lines, DiffLine{Op: DiffEqual, OldLine: i + 1, NewLine: i + 1, Text: a[i]})
+	}
+
+	for _, line := range myersDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		if line.OldLine > 0 {
+			line.OldLine += prefix
+		}
+		if line.NewLine > 0 {
+			line.NewLine += prefix
+		}
+		lines = append(lines, line)
+	}
+
+	for i := suffix; i > 0; i-- {
+		oldIndex, newIndex := len(a)-i, len(b)-i
+		lines = append(lines, DiffLine{Op: DiffEqual, OldLine: oldIndex + 1, NewLine: newIndex + 1, Text: a[oldIndex]})
+	}
+
+	return lines
+}
+
+func myersDiff(a, b []string) []DiffLine {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replaceAll(a, b)
+	}
+
+	maxD := n + m
+	if maxD > maxDiffEdits {
+		maxD = maxDiffEdits
+	}
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// trace[d]는 d번째 단계를 시작하기 전의 v에서 k = -d..d 구간입니다
+	var trace [][]int
+
+	found := false
+	for d := 0; d <= maxD && !found; d++ {
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return replaceAll(a, b)
+	}
+
+	// 끝에서부터 거꾸로 따라가며 편집 순서를 복원합니다
+	reversed := make([]DiffLine, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d]
+		at := func(k int) int { return prev[k+d] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, DiffLine{Op: DiffEqual, OldLine: x, NewLine: y, Text: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			reversed = append(reversed, DiffLine{Op: DiffInsert, NewLine: y, Text: b[y-1]})
+			y--
+		} else {
+			reversed = append(reversed, DiffLine{Op: DiffDelete, OldLine: x, Text: a[x-1]})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		reversed = append(reversed, DiffLine{Op: DiffEqual, OldLine: x, NewLine: y, Text: a[x-1]})
+		x--
+		y--
+	}
+
+	lines := make([]DiffLine, len(reversed))
+	for i, line := range reversed {
+		lines[len(reversed)-1-i] = line
+	}
+	return lines
+}
+
+func replaceAll(a, b []string) []DiffLine {
+	lines := make([]DiffLine, 0, len(a)+len(b))
+	for i, text := range a {
+		lines = append(lines, DiffLine{Op: DiffDelete, OldLine: i + 1, Text: text})
+	}
+	for i, text := range b {
+		lines = append(lines, DiffLine{Op: DiffInsert, NewLine: i + 1, Text: text})
+	}
+	return lines
+}
@@ -4,9 +4,11 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"fmt"
+	"html"
 	"math/big"
 	"net/smtp"
 	"portfolio-server/internal/config"
+	"time"
 )
 
 // GenerateVerificationCode generates a 6-digit verification code
@@ -24,22 +26,105 @@ func GenerateVerificationCode() (string, error) {
 
 // SendVerificationEmail sends a verification code to the user's email
 func SendVerificationEmail(email, code string) error {
-	cfg := config.LoadConfig()
+	content := `<p style="margin: 0 0 24px 0; color: #666; font-size: 15px; line-height: 1.6;">
+                                회원가입을 완료하려면 아래 인증 코드를 입력해주세요.
+                            </p>
+                            <table width="100%" cellpadding="0" cellspacing="0" border="0" style="margin: 0 0 24px 0;">
+                                <tr>
+                                    <td style="background-color: #f8f8f8; padding: 24px; text-align: center; border: 1px solid #e0e0e0;">
+                                        <div style="font-size: 36px; font-weight: 600; color: #3F35FF; letter-spacing: 6px; font-family: 'Courier New', monospace;">
+                                            ` + code + `
+                                        </div>
+                                    </td>
+                                </tr>
+                            </table>
+                            <p style="margin: 0 0 8px 0; color: #999; font-size: 13px; line-height: 1.5;">
+                                유효 시간: 10분
+                            </p>`
 
-	// SMTP 설정
-	from := cfg.SMTP.From
-	password := cfg.SMTP.Password
-	smtpHost := cfg.SMTP.Host
-	smtpPort := cfg.SMTP.Port
+	return sendEmail(email, "이메일 인증 코드", renderEmail("이메일 인증", "회원가입 인증 코드입니다.", content))
+}
+
+// SendPasswordResetEmail sends a password reset link to the user's email
+func SendPasswordResetEmail(email, resetURL string) error {
+	link := html.EscapeString(resetURL)
+	content := `<p style="margin: 0 0 24px 0; color: #666; font-size: 15px; line-height: 1.6;">
+                                아래 버튼을 눌러 새 비밀번호를 설정해주세요. 비밀번호를 변경하면 모든 기기에서 로그아웃됩니다.
+                            </p>
+                            <table cellpadding="0" cellspacing="0" border="0" style="margin: 0 0 24px 0;">
+                                <tr>
+                                    <td style="background-color: #3F35FF; padding: 14px 28px;">
+                                        <a href="` + link + `" style="color: #ffffff; font-size: 15px; font-weight: 600; text-decoration: none;">비밀번호 재설정</a>
+                                    </td>
+                                </tr>
+                            </table>
+                            <p style="margin: 0 0 8px 0; color: #999; font-size: 13px; line-height: 1.5; word-break: break-all;">
+                                버튼이 동작하지 않으면 다음 주소를 브라우저에 붙여넣으세요: ` + link + `
+                            </p>
+                            <p style="margin: 0 0 8px 0; color: #999; font-size: 13px; line-height: 1.5;">
+                                유효 시간: 30분
+                            </p>`
+
+	return sendEmail(email, "비밀번호 재설정", renderEmail("비밀번호 재설정", "비밀번호 재설정 요청을 받았습니다.", content))
+}
+
+// SendMagicLinkEmail sends a single-use login link to the user's email
+func SendMagicLinkEmail(email, loginURL string) error {
+	link := html.EscapeString(loginURL)
+	content := `<p style="margin: 0 0 24px 0; color: #666; font-size: 15px; line-height: 1.6;">
+                                아래 버튼을 누르면 비밀번호 없이 로그인됩니다. 링크는 한 번만 사용할 수 있습니다.
+                            </p>
+                            <table cellpadding="0" cellspacing="0" border="0" style="margin: 0 0 24px 0;">
+                                <tr>
+                                    <td style="background-color: #3F35FF; padding: 14px 28px;">
+                                        <a href="` + link + `" style="color: #ffffff; font-size: 15px; font-weight: 600; text-decoration: none;">로그인</a>
+                                    </td>
+                                </tr>
+                            </table>
+                            <p style="margin: 0 0 8px 0; color: #999; font-size: 13px; line-height: 1.5; word-break: break-all;">
+                                버튼이 동작하지 않으면 다음 주소를 브라우저에 붙여넣으세요: ` + link + `
+                            </p>
+                            <p style="margin: 0 0 8px 0; color: #999; font-size: 13px; line-height: 1.5;">
+                                유효 시간: 15분
+                            </p>`
+
+	return sendEmail(email, "로그인 링크", renderEmail("로그인 링크", "로그인 링크 요청을 받았습니다.", content))
+}
+
+// SendLoginFailureAlertEmail warns the owner that someone keeps entering a wrong password
+func SendLoginFailureAlertEmail(email string, failures int, ip string) error {
+	content := `<p style="margin: 0 0 24px 0; color: #666; font-size: 15px; line-height: 1.6;">
+                                계정에 잘못된 비밀번호로 ` + fmt.Sprintf("%d", failures) + `회 로그인 시도가 있었습니다. (IP: ` + html.EscapeString(ip) + `)
+                            </p>
+                            <p style="margin: 0 0 24px 0; color: #666; font-size: 15px; line-height: 1.6;">
+                                본인이 시도한 것이 아니라면 비밀번호를 변경하고 2단계 인증을 설정해주세요.
+                            </p>`
+
+	return sendEmail(email, "로그인 실패 알림", renderEmail("로그인 실패 알림", "반복된 로그인 실패가 감지되었습니다.", content))
+}
 
-	// 이메일 내용
-	subject := "이메일 인증 코드"
-	body := `<!DOCTYPE html>
+// SendNewLoginAlertEmail tells the owner about a login from an IP and browser not seen before
+func SendNewLoginAlertEmail(email, ip, userAgent string, at time.Time) error {
+	content := `<table width="100%" cellpadding="0" cellspacing="0" border="0" style="margin: 0 0 24px 0; color: #666; font-size: 14px; line-height: 1.8;">
+                                <tr><td style="width: 80px; color: #999;">시각</td><td>` + at.Format("2006-01-02 15:04:05 MST") + `</td></tr>
+                                <tr><td style="color: #999;">IP</td><td>` + html.EscapeString(ip) + `</td></tr>
+                                <tr><td style="color: #999;">기기</td><td style="word-break: break-all;">` + html.EscapeString(userAgent) + `</td></tr>
+                            </table>
+                            <p style="margin: 0 0 24px 0; color: #666; font-size: 15px; line-height: 1.6;">
+                                본인이 로그인한 것이 아니라면 즉시 비밀번호를 변경하고 세션 목록에서 해당 기기를 로그아웃해주세요.
+                            </p>`
+
+	return sendEmail(email, "새로운 기기에서 로그인", renderEmail("새로운 기기에서 로그인", "처음 보는 환경에서 계정에 로그인했습니다.", content))
+}
+
+// renderEmail wraps content in the common mail layout
+func renderEmail(title, subtitle, content string) string {
+	return `<!DOCTYPE html>
 <html lang="ko">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>이메일 인증</title>
+    <title>` + title + `</title>
 </head>
 <body style="margin: 0; padding: 0; background-color: #fafafa; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', sans-serif;">
     <table width="100%" cellpadding="0" cellspacing="0" border="0" style="background-color: #fafafa;">
@@ -48,27 +133,13 @@ func SendVerificationEmail(email, code string) error {
                 <table width="100%" cellpadding="0" cellspacing="0" border="0" style="max-width: 560px; margin: 0 auto; background-color: #ffffff;">
                     <tr>
                         <td style="padding: 48px 40px 32px 40px;">
-                            <h1 style="margin: 0 0 8px 0; color: #434a53; font-size: 24px; font-weight: 600;">이메일 인증</h1>
-                            <p style="margin: 0; color: #999; font-size: 14px;">회원가입 인증 코드입니다.</p>
+                            <h1 style="margin: 0 0 8px 0; color: #434a53; font-size: 24px; font-weight: 600;">` + title + `</h1>
+                            <p style="margin: 0; color: #999; font-size: 14px;">` + subtitle + `</p>
                         </td>
                     </tr>
                     <tr>
                         <td style="padding: 0 40px 40px 40px;">
-                            <p style="margin: 0 0 24px 0; color: #666; font-size: 15px; line-height: 1.6;">
-                                회원가입을 완료하려면 아래 인증 코드를 입력해주세요.
-                            </p>
-                            <table width="100%" cellpadding="0" cellspacing="0" border="0" style="margin: 0 0 24px 0;">
-                                <tr>
-                                    <td style="background-color: #f8f8f8; padding: 24px; text-align: center; border: 1px solid #e0e0e0;">
-                                        <div style="font-size: 36px; font-weight: 600; color: #3F35FF; letter-spacing: 6px; font-family: 'Courier New', monospace;">
-                                            ` + code + `
-                                        </div>
-                                    </td>
-                                </tr>
-                            </table>
-                            <p style="margin: 0 0 8px 0; color: #999; font-size: 13px; line-height: 1.5;">
-                                유효 시간: 10분
-                            </p>
+                            ` + content + `
                             <p style="margin: 0; color: #999; font-size: 13px; line-height: 1.5;">
                                 본인이 요청하지 않은 경우 이 메일을 무시하세요.
                             </p>
@@ -87,6 +158,17 @@ func SendVerificationEmail(email, code string) error {
     </table>
 </body>
 </html>`
+}
+
+// sendEmail delivers an HTML mail through the configured SMTP server using STARTTLS
+func sendEmail(email, subject, body string) error {
+	cfg := config.LoadConfig()
+
+	// SMTP 설정
+	from := cfg.SMTP.From
+	password := cfg.SMTP.Password
+	smtpHost := cfg.SMTP.Host
+	smtpPort := cfg.SMTP.Port
 
 	// 메시지 구성
 	message := []byte(
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"portfolio-server/internal/models"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// 원본 HTML은 그대로 렌더링한 뒤 sanitizer에서 허용된 태그만 남깁니다.
+// 제목 ID는 파서 대신 RenderMarkdown이 제목의 텍스트로 붙입니다
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+var markdownPolicy = newMarkdownPolicy()
+
+func newMarkdownPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	// 목차 링크가 가리키는 제목 ID (한글 포함)
+	policy.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	// 코드 블록 언어. 서버는 하이라이팅하지 않으며 클라이언트의 하이라이터(Prism, highlight.js)가 이 클래스를 사용합니다
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+	// 작업 목록 체크박스
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+	policy.RequireNoReferrerOnLinks(true)
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	return policy
+}
+
+// headingIDs gives headings slug anchors like "go-언어-배우기", numbering repeats ("-1", "-2")
+type headingIDs struct {
+	used map[string]bool
+}
+
+// generate makes the anchor from the heading's plain text, so markup such as emphasis or inline
+// HTML tags never ends up in the ID
+func (h *headingIDs) generate(text string) string {
+	base := Slugify(text, SlugOptions{KeepHangul: true, Fallback: "section"})
+	id := base
+	for i := 1; h.used[id]; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	h.used[id] = true
+	return id
+}
+
+// RenderMarkdown converts Markdown to sanitized HTML and collects the table of contents from its headings.
+// Raw HTML in the source is kept only as far as the allow list permits; scripts, event handlers and
+// unsafe link schemes are removed.
+func RenderMarkdown(source string) (string, []models.TOCEntry, error) {
+	src := []byte(source)
+	document := markdown.Parser().Parse(text.NewReader(src))
+
+	ids := &headingIDs{used: make(map[string]bool)}
+	toc := make([]models.TOCEntry, 0)
+	err := ast.Walk(document, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := node.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		title := strings.TrimSpace(nodeText(heading, src))
+		id := ids.generate(title)
+		heading.SetAttributeString("id", []byte(id))
+		toc = append(toc, models.TOCEntry{
+			Level: heading.Level,
+			Text:  title,
+			ID:    id,
+		})
+		return ast.WalkSkipChildren, nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build table of contents: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := markdown.Renderer().Render(&buf, src, document); err != nil {
+		return "", nil, fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	return markdownPolicy.Sanitize(buf.String()), toc, nil
+}
+
+// nodeText concatenates the text of the node's inline children without their markup
+func nodeText(node ast.Node, source []byte) string {
+	var b strings.Builder
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		switch n := child.(type) {
+		case *ast.Text:
+			b.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(n.Value)
+		case *ast.RawHTML:
+			// 제목 안의 HTML 태그는 목차에 넣지 않습니다
+		default:
+			b.WriteString(nodeText(child, source))
+		}
+	}
+	return b.String()
+}
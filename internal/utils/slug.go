@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 슬러그의 최대 길이 (문자 수)
+const maxSlugLength = 80
+
+// SlugOptions controls how titles become slugs
+type SlugOptions struct {
+	// KeepHangul keeps Korean syllables as they are instead of romanizing them
+	KeepHangul bool
+	// Fallback is used when nothing usable is left of the title, e.g. a title made only of emoji
+	Fallback string
+}
+
+// 한글 음절은 초성 19 x 중성 21 x 종성 28 조합으로 U+AC00부터 배치되어 있습니다
+const (
+	hangulBase  = 0xAC00
+	hangulLast  = 0xD7A3
+	medialCount = 21
+	finalCount  = 28
+)
+
+// 국어의 로마자 표기법(2000)을 따르되 음운 변화는 연음만 반영합니다
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulMedials  = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+	// 다음 음절이 ㅇ으로 시작할 때 받침이 넘어가며 나는 소리 (겹받침은 제외)
+	hangulLinkedFinals = map[int]string{1: "g", 2: "kk", 4: "n", 7: "d", 8: "r", 16: "m", 17: "b", 19: "s", 20: "ss", 22: "j", 23: "ch", 24: "k", 25: "t", 26: "p", 27: ""}
+)
+
+// Slugify turns a title into a lowercase, dash separated slug such as "go-eoneo-baeugi".
+// Latin letters and digits are kept, Korean is romanized unless KeepHangul is set,
+// and everything else separates words.
+func Slugify(title string, options SlugOptions) string {
+	var b strings.Builder
+	runes := []rune(strings.ToLower(title))
+	pendingDash := false
+
+	write := func(s string) {
+		if s == "" {
+			return
+		}
+		if pendingDash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		pendingDash = false
+		b.WriteString(s)
+	}
+
+	for i, r := range runes {
+		switch {
+		case r >= hangulBase && r <= hangulLast:
+			if options.KeepHangul {
+				write(string(r))
+				continue
+			}
+			next := rune(0)
+			if i+1 < len(runes) {
+				next = runes[i+1]
+			}
+			write(romanizeSyllable(r, next))
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			write(string(r))
+		case r == '\'' || r == '’':
+			// "don't" -> "dont"
+		default:
+			pendingDash = true
+		}
+	}
+
+	slug := truncateSlug(b.String())
+	if slug == "" {
+		return options.Fallback
+	}
+	return slug
+}
+
+// romanizeSyllable romanizes one Hangul syllable. next is the following character, used to
+// carry a final consonant over to a syllable that starts with a silent ㅇ (한국어 -> hangugeo).
+func romanizeSyllable(r, next rune) string {
+	index := int(r - hangulBase)
+	initial := index / (medialCount * finalCount)
+	medial := (index % (medialCount * finalCount)) / finalCount
+	final := index % finalCount
+
+	coda := hangulFinals[final]
+	if next >= hangulBase && next <= hangulLast && int(next-hangulBase)/(medialCount*finalCount) == 11 {
+		if linked, ok := hangulLinkedFinals[final]; ok {
+			coda = linked
+		}
+	}
+
+	return hangulInitials[initial] + hangulMedials[medial] + coda
+}
+
+// truncateSlug cuts the slug to maxSlugLength characters, preferably at a word boundary
+func truncateSlug(slug string) string {
+	runes := []rune(slug)
+	if len(runes) <= maxSlugLength {
+		return slug
+	}
+	cut := runes[:maxSlugLength]
+	for i := len(cut) - 1; i > maxSlugLength/2; i-- {
+		if cut[i] == '-' {
+			cut = cut[:i]
+			break
+		}
+	}
+	return strings.Trim(string(cut), "-")
+}
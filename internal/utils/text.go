@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// 분당 읽는 단어 수. 한국어는 띄어쓰기 단위(어절)로 셉니다
+const wordsPerMinute = 200
+
+var (
+	markdownFence      = regexp.MustCompile("(?ms)^\\s*(```|~~~).*?^\\s*(```|~~~)[^\\n]*$")
+	markdownImage      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLink       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	markdownRefLink    = regexp.MustCompile(`(?m)^\s*\[[^\]]+\]:\s*\S+.*$`)
+	markdownLinePrefix = regexp.MustCompile(`(?m)^\s{0,3}(#{1,6}\s+|>\s?|[-*+]\s+|\d+[.)]\s+)+`)
+	markdownRule       = regexp.MustCompile(`(?m)^\s*([-*_]\s*){3,}$`)
+	markdownEmphasis   = regexp.MustCompile("(\\*{1,3}|_{2,3}|~~|`+)")
+	htmlTag            = regexp.MustCompile(`<[^>]*>`)
+)
+
+// PlainText strips Markdown and HTML markup from content, leaving the words a reader would see
+// separated by single spaces. Code blocks are dropped since they read poorly in an excerpt.
+func PlainText(content string) string {
+	text := strings.ReplaceAll(content, "\r\n", "\n")
+	text = markdownFence.ReplaceAllString(text, "")
+	text = markdownImage.ReplaceAllString(text, "$1")
+	text = markdownLink.ReplaceAllString(text, "$1")
+	text = markdownRefLink.ReplaceAllString(text, "")
+	text = markdownRule.ReplaceAllString(text, "")
+	text = markdownLinePrefix.ReplaceAllString(text, "")
+	text = markdownEmphasis.ReplaceAllString(text, "")
+	text = htmlTag.ReplaceAllString(text, " ")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Excerpt shortens text to at most maxRunes characters, cutting at a word boundary when one is
+// close enough and adding an ellipsis when anything was cut.
+func Excerpt(text string, maxRunes int) string {
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := maxRunes - 1
+	// 단어 중간에서 자르지 않도록 앞쪽 공백까지 물러나되, 너무 짧아지면 그냥 자릅니다
+	for i := cut; i > maxRunes/2; i-- {
+		if runes[i] == ' ' {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimRight(string(runes[:cut]), " .,") + "…"
+}
+
+// CountWords counts the space separated words of plain text
+func CountWords(text string) int {
+	return len(strings.Fields(text))
+}
+
+// ReadingTime estimates the minutes needed to read the given number of words, at least one minute
+// for any text at all.
+func ReadingTime(words int) int {
+	if words == 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}